@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"github/pheethy/todo/config"
+)
+
+const (
+	apiKeyPrefix    = "todo_"
+	apiKeyPrefixLen = len(apiKeyPrefix) + 8
+)
+
+/* NewApiKey สุ่ม key ใหม่ คืน key เต็มกับ prefix ที่ใช้แสดงให้ผู้ใช้จำได้ว่าเป็น key ไหน */
+func NewApiKey() (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	key := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(buf)
+	return key, key[:apiKeyPrefixLen], nil
+}
+
+/* HashApiKey HMAC-SHA256 ของ key โดยใช้ JWT_API_KEY เป็น secret ถ้าฐานข้อมูลหลุด key ที่เก็บไว้ก็เอาไปใช้ไม่ได้ */
+func HashApiKey(cfg config.IJwtConfig, key string) string {
+	mac := hmac.New(sha256.New, cfg.ApiKey())
+	mac.Write([]byte(key))
+	return hex.EncodeToString(mac.Sum(nil))
+}
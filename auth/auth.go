@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github/pheethy/todo/config"
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/models"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type TokenType string
+
+const (
+	Access  TokenType = "access"
+	Refresh TokenType = "refresh"
+)
+
+type contextKey string
+
+const userContextKey contextKey = "user"
+
+type AuthClaims struct {
+	Claims *models.UserClaims `json:"claims"`
+	jwt.RegisteredClaims
+}
+
+func newToken(cfg config.IJwtConfig, tokenType TokenType, claims *models.UserClaims, expiresIn int) (string, error) {
+	var now = time.Now()
+	jti, _ := uuid.NewV4()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &AuthClaims{
+		Claims: claims,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti.String(),
+			Issuer:    "todo-api",
+			Subject:   string(tokenType),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Duration(expiresIn) * time.Second)),
+		},
+	})
+
+	return token.SignedString(signingKey(cfg, claims))
+}
+
+/* signingKey token ของ admin ถูกเซ็นด้วย JWT_ADMIN_KEY แยกจากผู้ใช้ทั่วไป */
+func signingKey(cfg config.IJwtConfig, claims *models.UserClaims) []byte {
+	if claims != nil && claims.RoleId == constants.ROLE_ADMIN {
+		return cfg.AdminKey()
+	}
+	return cfg.SecretKey()
+}
+
+/* NewAccessToken ออก access token อายุตาม JWT_ACCESS_EXPIRES */
+func NewAccessToken(cfg config.IJwtConfig, claims *models.UserClaims) (string, error) {
+	return newToken(cfg, Access, claims, cfg.AccessExpiresAt())
+}
+
+/* NewRefreshToken ออก refresh token อายุตาม JWT_REFRESH_EXPIRES */
+func NewRefreshToken(cfg config.IJwtConfig, claims *models.UserClaims) (string, error) {
+	return newToken(cfg, Refresh, claims, cfg.RefreshExpiresAt())
+}
+
+/* ParseToken ตรวจลายเซ็นด้วย key ตาม role ใน token และตรวจว่าเป็น token ชนิดที่ต้องการ */
+func ParseToken(cfg config.IJwtConfig, tokenType TokenType, tokenString string) (*AuthClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &AuthClaims{}, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+		claims, _ := t.Claims.(*AuthClaims)
+		if claims == nil {
+			return nil, errors.New(constants.ERROR_TOKEN_INVALID)
+		}
+		return signingKey(cfg, claims.Claims), nil
+	})
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, errors.New(constants.ERROR_TOKEN_EXPIRED)
+		}
+		return nil, errors.New(constants.ERROR_TOKEN_INVALID)
+	}
+
+	claims, ok := token.Claims.(*AuthClaims)
+	if !ok || !token.Valid || claims.Subject != string(tokenType) || claims.Claims == nil {
+		return nil, errors.New(constants.ERROR_TOKEN_INVALID)
+	}
+
+	return claims, nil
+}
+
+/*
+SystemUser ผู้ใช้ของงานที่ไม่ได้มาจาก request เช่น purge job, seed และ export มีสิทธิ์เท่า admin
+usecase ไม่อนุญาต context ที่ไม่มีผู้ใช้ งานเหล่านี้จึงต้องใส่ผู้ใช้นี้ผ่าน WithSystemUser เอง
+*/
+var SystemUser = &models.UserClaims{Id: "system", Username: "system", RoleId: constants.ROLE_ADMIN}
+
+func WithSystemUser(ctx context.Context) context.Context {
+	return WithUser(ctx, SystemUser)
+}
+
+func WithUser(ctx context.Context, user *models.UserClaims) context.Context {
+	return context.WithValue(ctx, userContextKey, user)
+}
+
+func IsAdmin(user *models.UserClaims) bool {
+	return user != nil && user.RoleId == constants.ROLE_ADMIN
+}
+
+/* UserFromContext คืนผู้ใช้ที่ middleware ใส่ไว้ใน request context, nil ถ้าไม่ได้ login */
+func UserFromContext(ctx context.Context) *models.UserClaims {
+	user, _ := ctx.Value(userContextKey).(*models.UserClaims)
+	return user
+}
@@ -0,0 +1,189 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github/pheethy/todo/config"
+	"github/pheethy/todo/migration/database"
+
+	"github.com/BlackMocca/sqlx"
+)
+
+/* exit code ที่ทุกคำสั่งใช้เหมือนกัน */
+const (
+	ExitOK    = 0
+	ExitError = 1
+	ExitUsage = 2
+)
+
+/* defaultEnvPath ไฟล์ config ที่ใช้เมื่อไม่ได้ระบุ --env ถ้าไม่มีไฟล์นี้จะใช้ค่าจาก environment อย่างเดียว */
+const defaultEnvPath = ".env"
+
+var (
+	/* errUsage คำสั่งถูกเรียกด้วย argument ไม่ถูกต้อง Run จะพิมพ์วิธีใช้และออกด้วย ExitUsage */
+	errUsage = errors.New("invalid usage")
+	/* errFlags flag ไม่ถูกต้อง package flag พิมพ์ error และวิธีใช้ไปแล้ว */
+	errFlags = errors.New("invalid flags")
+)
+
+type command struct {
+	name    string
+	args    string
+	summary string
+	run     func(ctx context.Context, flags *flag.FlagSet, conf *configFlags, args []string) error
+}
+
+func commands() []command {
+	return []command{
+		{name: "serve", summary: "start the http server", run: runServe},
+		{name: "migrate", args: "<up|down [N]|goto V|status|force V>", summary: "apply or roll back database migrations", run: runMigrate},
+		{name: "seed", summary: "load fixtures (idempotent) and generate random tasks", run: runSeed},
+		{name: "create-admin", summary: "create a user with the admin role", run: runCreateAdmin},
+		{name: "purge-trash", summary: "permanently delete tasks in the trash", run: runPurgeTrash},
+		{name: "export", summary: "export tasks as json or csv", run: runExport},
+		{name: "config", args: "print [--redacted]", summary: "show the effective config and where each value comes from", run: runConfig},
+	}
+}
+
+/*
+Run เรียกคำสั่งตาม args[0] และคืน exit code
+ไม่ระบุคำสั่งจะเปิด server ด้วยไฟล์ env ค่าเริ่มต้น เหมือนการรัน binary แบบเดิม
+*/
+func Run(args []string) int {
+	if len(args) == 0 {
+		args = []string{"serve"}
+	}
+
+	if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printUsage()
+		return ExitOK
+	}
+
+	for _, cmd := range commands() {
+		if cmd.name != args[0] {
+			continue
+		}
+
+		var flags = flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+		var conf = newConfigFlags(flags)
+		flags.Usage = func() {
+			fmt.Fprintf(flags.Output(), "usage: todo %s [flags] %s\n\n", cmd.name, cmd.args)
+			flags.PrintDefaults()
+		}
+
+		err := cmd.run(context.Background(), flags, conf, args[1:])
+		switch {
+		case err == nil:
+			return ExitOK
+		case errors.Is(err, flag.ErrHelp):
+			return ExitOK
+		case errors.Is(err, errFlags):
+			return ExitUsage
+		case errors.Is(err, errUsage):
+			flags.Usage()
+			return ExitUsage
+		}
+		log.Printf("%s: %v", cmd.name, err)
+		return ExitError
+	}
+
+	fmt.Fprintf(os.Stderr, "unknown command %q\n\n", args[0])
+	printUsage()
+	return ExitUsage
+}
+
+func printUsage() {
+	var b strings.Builder
+	b.WriteString("usage: todo <command> [--env .env] [--set KEY=VALUE] [flags]\n\ncommands:\n")
+	for _, cmd := range commands() {
+		fmt.Fprintf(&b, "  %-14s %s\n", cmd.name, cmd.summary)
+	}
+	b.WriteString("\nrun 'todo <command> -h' for the flags of each command\n")
+	fmt.Fprint(os.Stderr, b.String())
+}
+
+/* parseFlags -h คืน flag.ErrHelp นอกนั้นที่ parse ไม่ผ่านคืน errFlags */
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errFlags
+	}
+	return nil
+}
+
+/* isFlagSet แยก flag ที่ไม่ได้ระบุออกจาก flag ที่ระบุเป็นค่า zero value */
+func isFlagSet(flags *flag.FlagSet, name string) bool {
+	var set bool
+	flags.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+/* connect โหลด config และเชื่อมต่อ database สำหรับคำสั่งที่ต้องใช้ database config ที่ไม่ถูกต้องจะคืน error ของทุก field */
+func connect(ctx context.Context, conf *configFlags) (config.Iconfig, *sqlx.DB, error) {
+	cfg, err := config.LoadSources(conf.sources())
+	if err != nil {
+		return nil, nil, err
+	}
+	return cfg, database.DBConnect(ctx, cfg.Db()), nil
+}
+
+/*
+configFlags flag ที่ทุกคำสั่งมีเหมือนกันสำหรับระบุแหล่งของ config
+ลำดับความสำคัญ: ค่าเริ่มต้น < ไฟล์ --env < environment ของ process < --set
+*/
+type configFlags struct {
+	flags *flag.FlagSet
+	file  *string
+	set   setFlag
+}
+
+func newConfigFlags(flags *flag.FlagSet) *configFlags {
+	var conf = &configFlags{flags: flags, set: make(setFlag)}
+	conf.file = flags.String("env", defaultEnvPath, "config file: .env, .yaml, .yml or .toml")
+	flags.Var(conf.set, "set", "override a config value as KEY=VALUE, can be repeated")
+	return conf
+}
+
+/* sources ไฟล์ค่าเริ่มต้นที่ไม่มีอยู่จะถูกข้าม แต่ไฟล์ที่ระบุด้วย --env ต้องมีอยู่จริง */
+func (c *configFlags) sources() config.Sources {
+	return config.Sources{
+		File:         *c.file,
+		FileOptional: !isFlagSet(c.flags, "env"),
+		Environ:      os.Environ(),
+		Flags:        c.set,
+	}
+}
+
+/* setFlag ค่าของ --set ที่ระบุซ้ำได้หลายครั้ง */
+type setFlag map[string]string
+
+func (s setFlag) String() string {
+	var pairs = make([]string, 0, len(s))
+	for key, value := range s {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (s setFlag) Set(value string) error {
+	key, val, ok := strings.Cut(value, "=")
+	if !ok || strings.TrimSpace(key) == "" {
+		return errors.New("must be KEY=VALUE")
+	}
+	s[strings.ToUpper(strings.TrimSpace(key))] = val
+	return nil
+}
@@ -0,0 +1,31 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunExitCode(t *testing.T) {
+	cases := map[string]struct {
+		args []string
+		code int
+	}{
+		"help":             {args: []string{"help"}, code: ExitOK},
+		"command_help":     {args: []string{"export", "-h"}, code: ExitOK},
+		"unknown_command":  {args: []string{"deploy"}, code: ExitUsage},
+		"unknown_flag":     {args: []string{"serve", "--port", "80"}, code: ExitUsage},
+		"unexpected_arg":   {args: []string{"serve", ".env"}, code: ExitUsage},
+		"migrate_no_arg":   {args: []string{"migrate"}, code: ExitUsage},
+		"migrate_goto_nan": {args: []string{"migrate", "goto", "latest"}, code: ExitUsage},
+		"export_format":    {args: []string{"export", "--format", "xml"}, code: ExitUsage},
+		"config_no_action": {args: []string{"config"}, code: ExitUsage},
+		"config_bad_set":   {args: []string{"config", "--set", "APP_PORT"}, code: ExitUsage},
+		"admin_no_user":    {args: []string{"create-admin", "--email", "admin@example.com"}, code: ExitUsage},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.code, Run(tc.args))
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github/pheethy/todo/config"
+)
+
+/*
+runConfig print แสดงค่า config ที่ใช้จริงหลังรวมทุกแหล่งพร้อมแหล่งที่มาของแต่ละค่า
+--redacted ซ่อน DB_PASSWORD และ JWT key ถ้า config ไม่ผ่าน validation จะแสดงค่าก่อนแล้วคืน error
+*/
+func runConfig(ctx context.Context, flags *flag.FlagSet, conf *configFlags, args []string) error {
+	var redacted = flags.Bool("redacted", false, "mask DB_PASSWORD and the JWT keys")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 || flags.Arg(0) != "print" {
+		return errUsage
+	}
+	/* รองรับ flag ที่อยู่หลัง print เช่น config print --redacted */
+	if err := parseFlags(flags, flags.Args()[1:]); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return errUsage
+	}
+
+	settings, err := config.Resolve(conf.sources())
+	if err != nil {
+		return err
+	}
+
+	var w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KEY\tVALUE\tSOURCE")
+	for _, setting := range settings {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", setting.Key, setting.Display(*redacted), setting.Source)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	_, err = config.FromEnv(settings.Env())
+	return err
+}
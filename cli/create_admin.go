@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github/pheethy/todo/models"
+	usersRepository "github/pheethy/todo/service/users/repository"
+	usersUsecase "github/pheethy/todo/service/users/usecase"
+
+	"github.com/gin-gonic/gin/binding"
+)
+
+/* runCreateAdmin ถ้าไม่ระบุ -password จะอ่านรหัสผ่านจาก stdin เพื่อไม่ให้รหัสผ่านค้างอยู่ใน shell history */
+func runCreateAdmin(ctx context.Context, flags *flag.FlagSet, conf *configFlags, args []string) error {
+	var req = new(models.UserSignUp)
+	flags.StringVar(&req.Username, "username", "", "username of the admin (required)")
+	flags.StringVar(&req.Email, "email", "", "email of the admin (required)")
+	flags.StringVar(&req.Password, "password", "", "password of the admin, read from stdin when empty")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 || req.Username == "" || req.Email == "" {
+		return errUsage
+	}
+
+	if req.Password == "" {
+		fmt.Fprint(os.Stderr, "password: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return fmt.Errorf("read password: %w", err)
+		}
+		req.Password = strings.TrimRight(line, "\r\n")
+	}
+	if err := binding.Validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	cfg, psqlDB, err := connect(ctx, conf)
+	if err != nil {
+		return err
+	}
+	defer psqlDB.Close()
+
+	usersUs := usersUsecase.NewUsersUsecase(cfg.Jwt(), usersRepository.NewUsersRepository(psqlDB))
+	user, err := usersUs.CreateAdmin(ctx, req)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("create-admin: %s (%s) created\n", user.Username, user.Id)
+	return nil
+}
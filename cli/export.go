@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github/pheethy/todo/auth"
+	"github/pheethy/todo/helper"
+	"github/pheethy/todo/models"
+)
+
+/* runExport ส่งออก task ที่ยังไม่ถูกลบ รูปแบบ json ใช้ import กลับผ่าน POST /tasks/import ได้ */
+func runExport(ctx context.Context, flags *flag.FlagSet, conf *configFlags, args []string) error {
+	var format = flags.String("format", "json", "output format: json or csv")
+	var out = flags.String("out", "-", "output file, - for stdout")
+	var status = flags.String("status", "", "export only tasks with this status")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 || (*format != "json" && *format != "csv") {
+		return errUsage
+	}
+
+	cfg, psqlDB, err := connect(ctx, conf)
+	if err != nil {
+		return err
+	}
+	defer psqlDB.Close()
+
+	todoUs, err := newTodoUsecase(ctx, cfg, psqlDB)
+	if err != nil {
+		return err
+	}
+
+	/* export ทุก task ไม่จำกัดตามเจ้าของ */
+	ctx = auth.WithSystemUser(ctx)
+	var filter = models.NewTaskFilter()
+	filter.Status = models.TaskStatus(*status)
+	var tasks = make([]*models.Task, 0)
+	var paginator = models.NewCursorPaginator(nil, models.MAX_PER_PAGE)
+	for {
+		page, err := todoUs.FetchListTodoByCursor(ctx, filter, paginator)
+		if err != nil {
+			return err
+		}
+		tasks = append(tasks, page...)
+		if paginator.NextCursor == "" {
+			break
+		}
+		cursor, err := models.DecodeTaskCursor(paginator.NextCursor)
+		if err != nil {
+			return err
+		}
+		paginator = models.NewCursorPaginator(cursor, models.MAX_PER_PAGE)
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "-" {
+		file, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+
+	if *format == "csv" {
+		err = writeTasksCsv(w, tasks)
+	} else {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(tasks)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "export: %d task(s) exported\n", len(tasks))
+	return nil
+}
+
+func writeTasksCsv(w io.Writer, tasks []*models.Task) error {
+	var writer = csv.NewWriter(w)
+	var timestamp = func(ts *helper.Timestamp) string {
+		if ts == nil {
+			return ""
+		}
+		return ts.String()
+	}
+
+	writer.Write([]string{"id", "task_name", "status", "creator_name", "created_at", "updated_at"})
+	for _, task := range tasks {
+		writer.Write([]string{
+			task.Id.String(),
+			task.TaskName,
+			string(task.Status),
+			task.CreatorName,
+			timestamp(task.CreatedAt),
+			timestamp(task.UpdatedAt),
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github/pheethy/todo/config"
+	"github/pheethy/todo/migration/database"
+
+	"github.com/BlackMocca/sqlx"
+)
+
+/*
+runMigrate ใช้ไฟล์ sql ที่ embed ไว้ใน binary
+
+	up           apply ทุก migration ที่ค้างอยู่
+	down [N]     ย้อน N migration (ค่าเริ่มต้น 1)
+	goto V       migrate ขึ้นหรือลงไปที่ version V (0 คือย้อนทั้งหมด)
+	status       แสดง version ปัจจุบันและ migration ที่ค้างอยู่
+	force V      ตั้ง version V โดยไม่รัน sql และล้างสถานะ dirty
+*/
+func runMigrate(ctx context.Context, flags *flag.FlagSet, conf *configFlags, args []string) error {
+	var source = flags.String("source", "", "migration set in migration/database (default DB_MIGRATION_SOURCE)")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return errUsage
+	}
+
+	var numberArg = func(required bool, def int) (int, error) {
+		if flags.NArg() < 2 {
+			if required {
+				return 0, errUsage
+			}
+			return def, nil
+		}
+		n, err := strconv.Atoi(flags.Arg(1))
+		if err != nil || n < 0 {
+			return 0, errUsage
+		}
+		return n, nil
+	}
+
+	var action func(migrator database.Migrator) error
+	switch flags.Arg(0) {
+	case "up":
+		action = func(migrator database.Migrator) error { return migrator.Up(ctx) }
+	case "down":
+		steps, err := numberArg(false, 1)
+		if err != nil {
+			return err
+		}
+		action = func(migrator database.Migrator) error { return migrator.Down(ctx, steps) }
+	case "goto", "force":
+		version, err := numberArg(true, 0)
+		if err != nil {
+			return err
+		}
+		if flags.Arg(0) == "goto" {
+			action = func(migrator database.Migrator) error { return migrator.Goto(ctx, uint(version)) }
+		} else {
+			action = func(migrator database.Migrator) error { return migrator.Force(ctx, uint(version)) }
+		}
+	case "status":
+		action = func(migrator database.Migrator) error { return nil }
+	default:
+		return errUsage
+	}
+
+	cfg, psqlDB, err := connect(ctx, conf)
+	if err != nil {
+		return err
+	}
+	defer psqlDB.Close()
+	if *source == "" {
+		*source = cfg.Db().MigrationSource()
+	}
+
+	migrator, err := database.NewMigrator(psqlDB, *source)
+	if err != nil {
+		return err
+	}
+	if err := action(migrator); err != nil {
+		return err
+	}
+	return printMigrationStatus(ctx, migrator)
+}
+
+func printMigrationStatus(ctx context.Context, migrator database.Migrator) error {
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("version: %d", status.Version)
+	if status.Dirty {
+		fmt.Print(" (dirty)")
+	}
+	fmt.Println()
+	for _, migration := range status.Applied {
+		fmt.Printf("  [x] %06d_%s\n", migration.Version, migration.Name)
+	}
+	for _, migration := range status.Pending {
+		fmt.Printf("  [ ] %06d_%s\n", migration.Version, migration.Name)
+	}
+	return nil
+}
+
+/* autoMigrate รัน migration ที่ค้างอยู่ทั้งหมดก่อนเปิด server เมื่อกำหนด DB_AUTO_MIGRATE=true */
+func autoMigrate(ctx context.Context, cfg config.IDbConfig, db *sqlx.DB) error {
+	if !cfg.AutoMigrate() {
+		return nil
+	}
+	migrator, err := database.NewMigrator(db, cfg.MigrationSource())
+	if err != nil {
+		return err
+	}
+	if err := migrator.Up(ctx); err != nil {
+		return fmt.Errorf("auto migrate: %w", err)
+	}
+	log.Println("auto migrate: database is up to date")
+	return nil
+}
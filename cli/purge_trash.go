@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github/pheethy/todo/auth"
+)
+
+/* runPurgeTrash ลบถังขยะครั้งเดียวแบบเดียวกับ purge job ใช้กับ cron ภายนอกได้ */
+func runPurgeTrash(ctx context.Context, flags *flag.FlagSet, conf *configFlags, args []string) error {
+	var olderThan = flags.Duration("older-than", 0, "purge tasks deleted longer than this, e.g. 720h (default APP_TRASH_RETENTION)")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return errUsage
+	}
+
+	cfg, psqlDB, err := connect(ctx, conf)
+	if err != nil {
+		return err
+	}
+	defer psqlDB.Close()
+	if !isFlagSet(flags, "older-than") {
+		*olderThan = cfg.App().TrashRetention()
+	}
+
+	todoUs, err := newTodoUsecase(ctx, cfg, psqlDB)
+	if err != nil {
+		return err
+	}
+	purged, err := todoUs.PurgeTrash(auth.WithSystemUser(ctx), *olderThan)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("purge-trash: %d task(s) removed\n", purged)
+	return nil
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github/pheethy/todo/auth"
+	"github/pheethy/todo/seed"
+	ordersRepository "github/pheethy/todo/service/orders/repository"
+	productsRepository "github/pheethy/todo/service/products/repository"
+	"github/pheethy/todo/service/todo/repository"
+	usersRepository "github/pheethy/todo/service/users/repository"
+)
+
+/*
+runSeed โหลด fixture ของ environment ที่ embed ไว้ (--fixtures) หรือจากโฟลเดอร์ (--dir)
+--random N สร้าง task สุ่มเพิ่ม N รายการสำหรับ load test ถ้าไม่ได้ระบุ --fixtures หรือ --dir จะสร้างเฉพาะ task สุ่ม
+*/
+func runSeed(ctx context.Context, flags *flag.FlagSet, conf *configFlags, args []string) error {
+	var fixturesEnv = flags.String("fixtures", "dev", "embedded fixtures to load: dev or test")
+	var dir = flags.String("dir", "", "load fixtures from this directory instead of the embedded ones")
+	var random = flags.Int("random", 0, "number of random tasks to generate")
+	var randomSeed = flags.Int64("random-seed", 1, "seed of the random generator, the same seed generates the same tasks")
+	var creator = flags.String("creator", "", "creator_name of random tasks (default: random sample users)")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 || *random < 0 {
+		return errUsage
+	}
+
+	var fixtures = new(seed.Fixtures)
+	if *random == 0 || isFlagSet(flags, "fixtures") || *dir != "" {
+		var fsys, name, source = seed.EmbeddedFixtures(), *fixturesEnv, "fixtures " + *fixturesEnv
+		if *dir != "" {
+			fsys, name, source = os.DirFS(*dir), ".", *dir
+		}
+		loaded, err := seed.LoadFixtures(fsys, name)
+		if err != nil {
+			return fmt.Errorf("%s: %w", source, err)
+		}
+		fixtures = loaded
+	}
+	fixtures.Tasks = append(fixtures.Tasks, seed.GenerateTasks(*random, *randomSeed, *creator)...)
+
+	_, psqlDB, err := connect(ctx, conf)
+	if err != nil {
+		return err
+	}
+	defer psqlDB.Close()
+
+	seeder := seed.NewSeeder(
+		usersRepository.NewUsersRepository(psqlDB),
+		productsRepository.NewProductsRepository(psqlDB),
+		ordersRepository.NewOrdersRepository(psqlDB),
+		repository.NewTodoRepository(psqlDB),
+	)
+	result, err := seeder.Seed(auth.WithSystemUser(ctx), fixtures)
+	fmt.Print(result)
+	return err
+}
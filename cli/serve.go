@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github/pheethy/todo/config"
+	"github/pheethy/todo/middleware"
+	"github/pheethy/todo/route"
+	"github/pheethy/todo/service/todo"
+	"github/pheethy/todo/storage"
+
+	"github.com/BlackMocca/sqlx"
+	"github.com/gin-gonic/gin"
+
+	apiKeysHandler "github/pheethy/todo/service/apikeys/handler"
+	apiKeysRepository "github/pheethy/todo/service/apikeys/repository"
+	apiKeysUsecase "github/pheethy/todo/service/apikeys/usecase"
+	ordersHandler "github/pheethy/todo/service/orders/handler"
+	ordersRepository "github/pheethy/todo/service/orders/repository"
+	ordersUsecase "github/pheethy/todo/service/orders/usecase"
+	productsHandler "github/pheethy/todo/service/products/handler"
+	productsRepository "github/pheethy/todo/service/products/repository"
+	productsUsecase "github/pheethy/todo/service/products/usecase"
+	"github/pheethy/todo/service/todo/handler"
+	"github/pheethy/todo/service/todo/job"
+	"github/pheethy/todo/service/todo/repository"
+	"github/pheethy/todo/service/todo/usecase"
+	usersHandler "github/pheethy/todo/service/users/handler"
+	usersRepository "github/pheethy/todo/service/users/repository"
+	usersUsecase "github/pheethy/todo/service/users/usecase"
+)
+
+/* runServe เปิด http server จนกว่าจะได้รับ SIGINT หรือ SIGTERM แล้วค่อยปิดแบบ graceful */
+func runServe(ctx context.Context, flags *flag.FlagSet, conf *configFlags, args []string) error {
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return errUsage
+	}
+
+	cfg, psqlDB, err := connect(ctx, conf)
+	if err != nil {
+		return err
+	}
+	defer psqlDB.Close()
+	if err := autoMigrate(ctx, cfg.Db(), psqlDB); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	r := gin.Default()
+
+	fileStorage, err := storage.NewStorage(ctx, cfg.App())
+	if err != nil {
+		return fmt.Errorf("create storage failed: %w", err)
+	}
+	if cfg.App().GCPBucket() == "" {
+		r.Static(storage.LocalUrlPrefix, storage.LocalDir)
+	}
+
+	todoUs, err := newTodoUsecase(ctx, cfg, psqlDB)
+	if err != nil {
+		return err
+	}
+	todoHand := handler.NewTodoHandler(todoUs)
+	usersRepo := usersRepository.NewUsersRepository(psqlDB)
+	usersUs := usersUsecase.NewUsersUsecase(cfg.Jwt(), usersRepo)
+	usersHand := usersHandler.NewUsersHandler(usersUs)
+	apiKeysRepo := apiKeysRepository.NewApiKeysRepository(psqlDB)
+	apiKeysUs := apiKeysUsecase.NewApiKeysUsecase(cfg.Jwt(), apiKeysRepo)
+	apiKeysHand := apiKeysHandler.NewApiKeysHandler(apiKeysUs)
+	productsRepo := productsRepository.NewProductsRepository(psqlDB)
+	productsUs := productsUsecase.NewProductsUsecase(cfg.App(), productsRepo, fileStorage)
+	productsHand := productsHandler.NewProductsHandler(productsUs)
+	ordersRepo := ordersRepository.NewOrdersRepository(psqlDB)
+	ordersUs := ordersUsecase.NewOrdersUsecase(ordersRepo, productsRepo)
+	ordersHand := ordersHandler.NewOrdersHandler(ordersUs)
+	mid := middleware.NewMiddleware(cfg, usersUs, apiKeysUs)
+	r.Use(mid.BodyLimit(), mid.Timeout())
+	route := route.NewRoute(r, mid)
+	route.RegisterRoute(todoHand, usersHand, apiKeysHand, productsHand, ordersHand)
+
+	go job.NewPurgeTrashJob(todoUs, cfg.App().TrashRetention()).Run(ctx)
+
+	r.GET("/", func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, "Hello web")
+	})
+
+	s := &http.Server{
+		Addr:              cfg.App().Url(),
+		Handler:           r,
+		ReadTimeout:       cfg.App().ReadTimeOut(),
+		ReadHeaderTimeout: cfg.App().ReadHeaderTimeOut(),
+		WriteTimeout:      middleware.ServerWriteTimeout(cfg.App().WriteTimeOut(), cfg.App().RouteTimeOuts()),
+		IdleTimeout:       cfg.App().IdleTimeOut(),
+		MaxHeaderBytes:    1 << 20,
+	}
+
+	var listenErr = make(chan error, 1)
+	go func() {
+		if err := s.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			listenErr <- err
+		}
+	}()
+
+	select {
+	case err := <-listenErr:
+		return fmt.Errorf("listen: %w", err)
+	case <-ctx.Done():
+	}
+	stop()
+	fmt.Println("shutting down gracefully.")
+
+	timeOutCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	return s.Shutdown(timeOutCtx)
+}
+
+/* newTodoUsecase todo usecase พร้อม storage ของไฟล์แนบ ใช้ร่วมกันระหว่าง serve และคำสั่งที่จัดการ task */
+func newTodoUsecase(ctx context.Context, cfg config.Iconfig, psqlDB *sqlx.DB) (todo.TodoUsecase, error) {
+	attachmentStorage, err := storage.NewAttachmentStorage(ctx, cfg.App())
+	if err != nil {
+		return nil, fmt.Errorf("create attachment storage failed: %w", err)
+	}
+	todoRepo := repository.NewTodoRepository(psqlDB)
+	return usecase.NewTodoUsecase(cfg.App(), todoRepo, attachmentStorage), nil
+}
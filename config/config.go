@@ -4,105 +4,114 @@ import (
 	"fmt"
 	"log"
 	"math"
-	"strconv"
+	"os"
 	"time"
+)
+
+/* defaultTrashRetention ระยะเวลาที่ task อยู่ในถังขยะก่อนถูกลบถาวร เมื่อไม่ได้กำหนด APP_TRASH_RETENTION */
+const defaultTrashRetention = 30 * 24 * time.Hour
+
+/* defaultMigrationSource ชุด migration ใน migration/database ที่ใช้เมื่อไม่ได้กำหนด DB_MIGRATION_SOURCE */
+const defaultMigrationSource = "postgres_task"
 
-	"github.com/joho/godotenv"
+const (
+	defaultProtocol = "tcp"
+	defaultSslMode  = "disable"
 )
 
-/* LoadConfig เป็นตัวดึงข้อมูลจาก env มาใส่ใน struct */
+/* ค่าเริ่มต้นของ timeout ที่ไม่บังคับระบุ หน่วยวินาที */
+const (
+	defaultIdleTimeout       = 120
+	defaultReadHeaderTimeout = 10
+)
+
+/* defaultRouteTimeouts route ที่รับไฟล์หรือข้อมูลจำนวนมากจึงต้องใช้เวลานานกว่า APP_WRTIE_TIMEOUT */
+const defaultRouteTimeouts = "POST /tasks/import=120,POST /task/:id/attachments=120,GET /task/:id/attachments/:attachment_id=120,POST /products/:id/images=120"
+
+/* LoadConfig เป็นตัวดึงข้อมูลจาก env มาใส่ใน struct ถ้า config ไม่ถูกต้องจะแสดงทุก field ที่ผิดแล้วหยุดโปรแกรม */
 func LoadConfig(path string) Iconfig {
-	envMap, err := godotenv.Read(path)
+	cfg, err := Load(path)
 	if err != nil {
-		log.Fatalf("load env failed: %v", err)
+		log.Fatalf("load config failed: %v", err)
 	}
+	return cfg
+}
+
+/*
+Load เหมือน LoadConfig แต่คืน error แทนการหยุดโปรแกรม error จาก validation เป็น *ValidationError
+ค่าจาก environment ของ process ทับค่าในไฟล์ ใช้ LoadSources เมื่อต้องการกำหนดแหล่งเอง
+*/
+func Load(path string) (Iconfig, error) {
+	return LoadSources(Sources{File: path, Environ: os.Environ()})
+}
 
-	return &config{
+/* FromEnv สร้าง config จาก env map และตรวจสอบทุก field ก่อนคืนค่า */
+func FromEnv(envMap map[string]string) (Iconfig, error) {
+	var r = newEnvReader(envMap)
+	var cfg = &config{
 		app: &app{
-			host: envMap["APP_HOST"],
-			port: func() int {
-				p, err := strconv.Atoi(envMap["APP_PORT"])
-				if err != nil {
-					log.Fatalf("Load App Port Failed: %v", err)
-				}
-				return p
-			}(),
-			name:    envMap["APP_NAME"],
-			version: envMap["APP_VERSION"],
-			readTimeOut: func() time.Duration {
-				t, err := strconv.Atoi(envMap["APP_READ_TIMEOUT"])
-				if err != nil {
-					log.Fatalf("Load Read Time Out Failed: %v", err)
-				}
-				return time.Duration(int64(t) * int64(math.Pow10(9)))
-			}(),
-			writeTimeOut: func() time.Duration {
-				t, err := strconv.Atoi(envMap["APP_WRTIE_TIMEOUT"])
-				if err != nil {
-					log.Fatalf("Load Write Time Out Failed: %v", err)
-				}
-				return time.Duration(int64(t) * int64(math.Pow10(9)))
-			}(),
-			bodyLimit: func() int {
-				limit, err := strconv.Atoi(envMap["APP_BODY_LIMIT"])
-				if err != nil {
-					log.Fatalf("Load Body Limit Failed: %v", err)
-				}
-
-				return limit
-			}(),
-			fileLimit: func() int {
-				limit, err := strconv.Atoi(envMap["APP_FILE_LIMIT"])
-				if err != nil {
-					log.Fatalf("Load File Limit Failed: %v", err)
-				}
-
-				return limit
-			}(),
-			gcpBucket: envMap["APP_GCP_BUCKET"],
+			host:              r.optional("APP_HOST", ""),
+			port:              r.intRange("APP_PORT", 1, maxPort),
+			name:              r.optional("APP_NAME", ""),
+			version:           r.optional("APP_VERSION", ""),
+			readTimeOut:       r.seconds("APP_READ_TIMEOUT", 1),
+			writeTimeOut:      r.seconds("APP_WRTIE_TIMEOUT", 1),
+			idleTimeOut:       r.optionalSeconds("APP_IDLE_TIMEOUT", defaultIdleTimeout*time.Second, 1),
+			readHeaderTimeOut: r.optionalSeconds("APP_READ_HEADER_TIMEOUT", 0, 1),
+			routeTimeOuts:     r.routeTimeouts("APP_ROUTE_TIMEOUTS", defaultRouteTimeouts),
+			bodyLimit:         r.intRange("APP_BODY_LIMIT", 1, math.MaxInt32),
+			fileLimit:         r.intRange("APP_FILE_LIMIT", 1, math.MaxInt32),
+			gcpBucket:         r.optional("APP_GCP_BUCKET", ""),
+			attachmentBucket:  r.optional("APP_GCP_ATTACHMENT_BUCKET", ""),
+			trashRetention:    r.optionalSeconds("APP_TRASH_RETENTION", defaultTrashRetention, 1),
 		},
 		db: &db{
-			host: envMap["DB_HOST"],
-			port: func() int {
-				p, err := strconv.Atoi(envMap["DB_PORT"])
-				if err != nil {
-					log.Fatalf("Load Port DB Failed: %v", err)
-				}
-				return p
-			}(),
-			protocol: envMap["DB_PROTOCOL"],
-			username: envMap["DB_USERNAME"],
-			password: envMap["DB_PASSWORD"],
-			database: envMap["DB_DATABASE"],
-			sslMode:  envMap["DB_SSL_MODE"],
-			maxConnection: func() int {
-				con, err := strconv.Atoi(envMap["DB_MAX_CONNECTIONS"])
-				if err != nil {
-					log.Fatalf("Load Max Connection Failed: %v", err)
-				}
-				return con
-			}(),
+			host:            r.required("DB_HOST"),
+			port:            r.intRange("DB_PORT", 1, maxPort),
+			protocol:        r.optional("DB_PROTOCOL", defaultProtocol),
+			username:        r.required("DB_USERNAME"),
+			password:        r.optional("DB_PASSWORD", ""),
+			database:        r.required("DB_DATABASE"),
+			sslMode:         r.oneOf("DB_SSL_MODE", defaultSslMode, sslModes),
+			maxConnection:   r.intRange("DB_MAX_CONNECTIONS", 1, maxConnections),
+			migrationSource: r.optional("DB_MIGRATION_SOURCE", defaultMigrationSource),
+			autoMigrate:     r.boolean("DB_AUTO_MIGRATE", false),
 		},
 		jwt: &jwt{
-			adminKey:  envMap["JWT_ADMIN_KEY"],
-			secretKey: envMap["JWT_SECRET_KEY"],
-			apiKey:    envMap["JWT_API_KEY"],
-			accessExpiresAt: func() int {
-				ex, err := strconv.Atoi(envMap["JWT_ACCESS_EXPIRES"])
-				if err != nil {
-					log.Fatalf("Load Access Expires Failed: %v", err)
-				}
-				return ex
-			}(),
-			refreshExpiresAt: func() int {
-				ref, err := strconv.Atoi(envMap["JWT_REFRESH_EXPIRES"])
-				if err != nil {
-					log.Fatalf("Load Refresh Expires Failed: %v", err)
-				}
-				return ref
-			}(),
+			adminKey:         r.secret("JWT_ADMIN_KEY", minSecretLength),
+			secretKey:        r.secret("JWT_SECRET_KEY", minSecretLength),
+			apiKey:           r.secret("JWT_API_KEY", minSecretLength),
+			accessExpiresAt:  r.intRange("JWT_ACCESS_EXPIRES", 1, math.MaxInt32),
+			refreshExpiresAt: r.intRange("JWT_REFRESH_EXPIRES", 1, math.MaxInt32),
 		},
 	}
+
+	/* ตรวจความสัมพันธ์ระหว่าง field เฉพาะเมื่อทั้งสองค่าอ่านได้ถูกต้อง */
+	if cfg.app.fileLimit > 0 && cfg.app.bodyLimit > 0 && cfg.app.fileLimit > cfg.app.bodyLimit {
+		r.fail("APP_FILE_LIMIT", "must not exceed APP_BODY_LIMIT (%d)", cfg.app.bodyLimit)
+	}
+	if cfg.app.readTimeOut > 0 && cfg.app.readHeaderTimeOut > cfg.app.readTimeOut {
+		r.fail("APP_READ_HEADER_TIMEOUT", "must not exceed APP_READ_TIMEOUT (%d)", int(cfg.app.readTimeOut/time.Second))
+	}
+	/* ไม่ได้ระบุ APP_READ_HEADER_TIMEOUT ใช้ค่าเริ่มต้นแต่ไม่เกิน APP_READ_TIMEOUT */
+	if cfg.app.readHeaderTimeOut == 0 {
+		cfg.app.readHeaderTimeOut = defaultReadHeaderTimeout * time.Second
+		if cfg.app.readTimeOut > 0 && cfg.app.readTimeOut < cfg.app.readHeaderTimeOut {
+			cfg.app.readHeaderTimeOut = cfg.app.readTimeOut
+		}
+	}
+	/* ไฟล์แนบ task เป็นข้อมูลส่วนตัว ห้ามอยู่ใน bucket เดียวกับรูปสินค้าที่เปิด public */
+	if cfg.app.attachmentBucket != "" && cfg.app.attachmentBucket == cfg.app.gcpBucket {
+		r.fail("APP_GCP_ATTACHMENT_BUCKET", "must not be the public APP_GCP_BUCKET")
+	}
+	if cfg.jwt.accessExpiresAt > 0 && cfg.jwt.refreshExpiresAt > 0 && cfg.jwt.refreshExpiresAt < cfg.jwt.accessExpiresAt {
+		r.fail("JWT_REFRESH_EXPIRES", "must not be less than JWT_ACCESS_EXPIRES (%d)", cfg.jwt.accessExpiresAt)
+	}
+
+	if err := r.err(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
 }
 
 // Struct
@@ -129,9 +138,16 @@ type IAppConfig interface {
 	Version() string
 	ReadTimeOut() time.Duration
 	WriteTimeOut() time.Duration
+	IdleTimeOut() time.Duration
+	ReadHeaderTimeOut() time.Duration
+	/* RouteTimeOuts timeout ของ route ที่ต่างจาก WriteTimeOut key คือ "METHOD /path" ตาม path ที่ลงทะเบียนใน gin */
+	RouteTimeOuts() map[string]time.Duration
 	BodyLimit() int
 	FileLimit() int
 	GCPBucket() string
+	/* GCPAttachmentBucket bucket แบบ private ของไฟล์แนบ task ค่าว่างคือเก็บในเครื่อง */
+	GCPAttachmentBucket() string
+	TrashRetention() time.Duration
 }
 
 func (a *app) Url() string {
@@ -149,6 +165,15 @@ func (a *app) ReadTimeOut() time.Duration {
 func (a *app) WriteTimeOut() time.Duration {
 	return a.writeTimeOut
 }
+func (a *app) IdleTimeOut() time.Duration {
+	return a.idleTimeOut
+}
+func (a *app) ReadHeaderTimeOut() time.Duration {
+	return a.readHeaderTimeOut
+}
+func (a *app) RouteTimeOuts() map[string]time.Duration {
+	return a.routeTimeOuts
+}
 func (a *app) BodyLimit() int {
 	return a.bodyLimit
 }
@@ -158,17 +183,28 @@ func (a *app) FileLimit() int {
 func (a *app) GCPBucket() string {
 	return a.gcpBucket
 }
+func (a *app) GCPAttachmentBucket() string {
+	return a.attachmentBucket
+}
+func (a *app) TrashRetention() time.Duration {
+	return a.trashRetention
+}
 
 type app struct {
-	host         string
-	port         int
-	name         string
-	version      string
-	readTimeOut  time.Duration
-	writeTimeOut time.Duration
-	bodyLimit    int //bytes
-	fileLimit    int //bytes
-	gcpBucket    string
+	host              string
+	port              int
+	name              string
+	version           string
+	readTimeOut       time.Duration
+	writeTimeOut      time.Duration
+	idleTimeOut       time.Duration
+	readHeaderTimeOut time.Duration
+	routeTimeOuts     map[string]time.Duration
+	bodyLimit         int //bytes
+	fileLimit         int //bytes
+	gcpBucket         string
+	attachmentBucket  string
+	trashRetention    time.Duration
 }
 
 func (c *config) Db() IDbConfig {
@@ -178,6 +214,8 @@ func (c *config) Db() IDbConfig {
 type IDbConfig interface {
 	Url() string
 	MaxConns() int
+	MigrationSource() string
+	AutoMigrate() bool
 }
 
 func (d *db) Url() string {
@@ -186,16 +224,24 @@ func (d *db) Url() string {
 func (d *db) MaxConns() int {
 	return d.maxConnection
 }
+func (d *db) MigrationSource() string {
+	return d.migrationSource
+}
+func (d *db) AutoMigrate() bool {
+	return d.autoMigrate
+}
 
 type db struct {
-	host          string
-	port          int
-	protocol      string
-	username      string
-	password      string
-	database      string
-	sslMode       string
-	maxConnection int
+	host            string
+	port            int
+	protocol        string
+	username        string
+	password        string
+	database        string
+	sslMode         string
+	maxConnection   int
+	migrationSource string
+	autoMigrate     bool
 }
 
 func (c *config) Jwt() IJwtConfig {
@@ -0,0 +1,104 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func validEnv() map[string]string {
+	return map[string]string{
+		"APP_PORT":            "8080",
+		"APP_READ_TIMEOUT":    "60",
+		"APP_WRTIE_TIMEOUT":   "60",
+		"APP_BODY_LIMIT":      "10490000",
+		"APP_FILE_LIMIT":      "2097000",
+		"JWT_ADMIN_KEY":       "76jfqJzzPJKhyKjk",
+		"JWT_SECRET_KEY":      "6t7hkJmVr5U2L5WL",
+		"JWT_API_KEY":         "v2UeyF3xDtCMmNCG",
+		"JWT_ACCESS_EXPIRES":  "86400",
+		"JWT_REFRESH_EXPIRES": "604800",
+		"DB_HOST":             "127.0.0.1",
+		"DB_PORT":             "5432",
+		"DB_USERNAME":         "postgres",
+		"DB_DATABASE":         "pheety_db_test",
+		"DB_MAX_CONNECTIONS":  "25",
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	cfg, err := FromEnv(validEnv())
+	assert.NoError(t, err)
+	assert.Equal(t, 60*time.Second, cfg.App().ReadTimeOut())
+	assert.Equal(t, defaultTrashRetention, cfg.App().TrashRetention())
+	assert.Equal(t, defaultMigrationSource, cfg.Db().MigrationSource())
+	assert.Contains(t, cfg.Db().Url(), "sslmode=disable")
+	assert.Equal(t, defaultReadHeaderTimeout*time.Second, cfg.App().ReadHeaderTimeOut())
+	assert.Equal(t, 120*time.Second, cfg.App().RouteTimeOuts()["POST /tasks/import"])
+}
+
+func TestFromEnvReadHeaderTimeout(t *testing.T) {
+	env := validEnv()
+	env["APP_READ_TIMEOUT"] = "5"
+	cfg, err := FromEnv(env)
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Second, cfg.App().ReadHeaderTimeOut())
+
+	env["APP_READ_HEADER_TIMEOUT"] = "3"
+	cfg, err = FromEnv(env)
+	assert.NoError(t, err)
+	assert.Equal(t, 3*time.Second, cfg.App().ReadHeaderTimeOut())
+
+	env["APP_READ_HEADER_TIMEOUT"] = "10"
+	_, err = FromEnv(env)
+	assert.ErrorContains(t, err, "APP_READ_HEADER_TIMEOUT")
+}
+
+func TestFromEnvAttachmentBucket(t *testing.T) {
+	env := validEnv()
+	env["APP_GCP_BUCKET"] = "public-images"
+	cfg, err := FromEnv(env)
+	assert.NoError(t, err)
+	assert.Equal(t, "", cfg.App().GCPAttachmentBucket())
+
+	env["APP_GCP_ATTACHMENT_BUCKET"] = "public-images"
+	_, err = FromEnv(env)
+	assert.ErrorContains(t, err, "APP_GCP_ATTACHMENT_BUCKET")
+
+	env["APP_GCP_ATTACHMENT_BUCKET"] = "private-attachments"
+	cfg, err = FromEnv(env)
+	assert.NoError(t, err)
+	assert.Equal(t, "private-attachments", cfg.App().GCPAttachmentBucket())
+}
+
+func TestFromEnvAggregatesErrors(t *testing.T) {
+	env := validEnv()
+	env["APP_PORT"] = "70000"
+	env["APP_READ_TIMEOUT"] = "abc"
+	env["APP_FILE_LIMIT"] = "20000000"
+	env["DB_HOST"] = ""
+	env["DB_MAX_CONNECTIONS"] = "0"
+	env["DB_SSL_MODE"] = "maybe"
+	env["JWT_SECRET_KEY"] = "short"
+	env["JWT_REFRESH_EXPIRES"] = "60"
+	env["APP_ROUTE_TIMEOUTS"] = "GET /tasks=30,/tasks/import=120"
+
+	cfg, err := FromEnv(env)
+	assert.Nil(t, cfg)
+	validationErr, ok := err.(*ValidationError)
+	if !assert.True(t, ok) {
+		return
+	}
+
+	var keys []string
+	for _, field := range validationErr.Fields {
+		keys = append(keys, field.Key)
+	}
+	assert.ElementsMatch(t, []string{
+		"APP_PORT", "APP_READ_TIMEOUT", "APP_FILE_LIMIT", "DB_HOST", "DB_MAX_CONNECTIONS",
+		"DB_SSL_MODE", "JWT_SECRET_KEY", "JWT_REFRESH_EXPIRES", "APP_ROUTE_TIMEOUTS",
+	}, keys)
+	assert.False(t, strings.Contains(err.Error(), "short"), "secret value must not be printed")
+}
@@ -0,0 +1,248 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+/* แหล่งของค่า config เรียงจากความสำคัญต่ำไปสูง */
+const (
+	SourceDefault = "default"
+	SourceFile    = "file"
+	SourceEnv     = "env"
+	SourceFlag    = "flag"
+	/* SourceUnset ไม่มีค่าในทุก layer */
+	SourceUnset = "unset"
+)
+
+/* redactedValue ค่าที่แสดงแทน secret */
+const redactedValue = "********"
+
+/* Keys ชื่อ config ทั้งหมดที่รองรับ เรียงตามกลุ่มเพื่อใช้แสดงผล */
+var Keys = []string{
+	"APP_HOST", "APP_PORT", "APP_NAME", "APP_VERSION", "APP_READ_TIMEOUT", "APP_WRTIE_TIMEOUT",
+	"APP_IDLE_TIMEOUT", "APP_READ_HEADER_TIMEOUT", "APP_ROUTE_TIMEOUTS", "APP_BODY_LIMIT", "APP_FILE_LIMIT", "APP_GCP_BUCKET",
+	"APP_GCP_ATTACHMENT_BUCKET", "APP_TRASH_RETENTION",
+	"DB_HOST", "DB_PORT", "DB_PROTOCOL", "DB_USERNAME", "DB_PASSWORD", "DB_DATABASE", "DB_SSL_MODE",
+	"DB_MAX_CONNECTIONS", "DB_MIGRATION_SOURCE", "DB_AUTO_MIGRATE",
+	"JWT_ADMIN_KEY", "JWT_SECRET_KEY", "JWT_API_KEY", "JWT_ACCESS_EXPIRES", "JWT_REFRESH_EXPIRES",
+}
+
+/* secretKeys ค่าที่ต้องซ่อนเมื่อแสดงแบบ redacted */
+var secretKeys = map[string]bool{
+	"DB_PASSWORD":    true,
+	"JWT_ADMIN_KEY":  true,
+	"JWT_SECRET_KEY": true,
+	"JWT_API_KEY":    true,
+}
+
+/*
+defaults layer ล่างสุด ค่าเดียวกับที่ FromEnv ใช้เมื่อไม่ได้ระบุ
+ไม่มี APP_READ_HEADER_TIMEOUT เพราะค่าเริ่มต้นขึ้นกับ APP_READ_TIMEOUT และ FromEnv ต้องรู้ว่าผู้ใช้ระบุเองหรือไม่
+*/
+var defaults = map[string]string{
+	"APP_IDLE_TIMEOUT":    strconv.Itoa(defaultIdleTimeout),
+	"APP_ROUTE_TIMEOUTS":  defaultRouteTimeouts,
+	"APP_TRASH_RETENTION": strconv.Itoa(int(defaultTrashRetention / time.Second)),
+	"DB_PROTOCOL":         defaultProtocol,
+	"DB_SSL_MODE":         defaultSslMode,
+	"DB_MIGRATION_SOURCE": defaultMigrationSource,
+	"DB_AUTO_MIGRATE":     "false",
+}
+
+/*
+Sources แหล่งของ config ที่จะนำมารวมกัน ค่าจากแหล่งหลังทับแหล่งก่อน
+default -> File -> Environ -> Flags
+*/
+type Sources struct {
+	/* File ไฟล์ .env, .yaml, .yml หรือ .toml ว่างคือไม่ใช้ไฟล์ */
+	File string
+	/* FileOptional ข้ามไฟล์ที่ไม่มีอยู่จริงแทนการคืน error ใช้กับ path ค่าเริ่มต้น */
+	FileOptional bool
+	/* Environ รูปแบบเดียวกับ os.Environ() ใช้เฉพาะ key ที่อยู่ใน Keys */
+	Environ []string
+	Flags   map[string]string
+}
+
+/* Setting ค่าที่ใช้จริงของ config หนึ่งตัวพร้อมแหล่งที่มา */
+type Setting struct {
+	Key    string
+	Value  string
+	Source string
+}
+
+/* Display ค่าที่ใช้แสดงผล secret ที่มีค่าจะถูกซ่อนเมื่อ redacted */
+func (s *Setting) Display(redacted bool) string {
+	if redacted && secretKeys[s.Key] && s.Value != "" {
+		return redactedValue
+	}
+	return s.Value
+}
+
+/* Settings config ที่รวมทุก layer แล้ว เรียงตาม Keys */
+type Settings []*Setting
+
+/* Env แปลงกลับเป็น env map สำหรับ FromEnv */
+func (s Settings) Env() map[string]string {
+	var env = make(map[string]string, len(s))
+	for _, setting := range s {
+		env[setting.Key] = setting.Value
+	}
+	return env
+}
+
+/* LoadSources รวม config จากทุกแหล่งแล้วตรวจสอบด้วย FromEnv */
+func LoadSources(src Sources) (Iconfig, error) {
+	settings, err := Resolve(src)
+	if err != nil {
+		return nil, err
+	}
+	return FromEnv(settings.Env())
+}
+
+/* Resolve รวมค่าจากทุก layer โดยยังไม่ตรวจสอบค่า ผลลัพธ์มีครบทุก key ใน Keys */
+func Resolve(src Sources) (Settings, error) {
+	var values = make(map[string]*Setting)
+	var apply = func(layer map[string]string, source string) {
+		for key, value := range layer {
+			values[key] = &Setting{Key: key, Value: value, Source: source}
+		}
+	}
+
+	apply(defaults, SourceDefault)
+
+	if src.File != "" {
+		file, err := readFile(src.File)
+		switch {
+		case errors.Is(err, fs.ErrNotExist) && src.FileOptional:
+		case err != nil:
+			return nil, err
+		default:
+			apply(file, SourceFile)
+		}
+	}
+
+	var env = make(map[string]string)
+	for _, kv := range src.Environ {
+		key, value, ok := strings.Cut(kv, "=")
+		if ok && isKnownKey(key) {
+			env[key] = value
+		}
+	}
+	apply(env, SourceEnv)
+
+	if err := checkKnownKeys(src.Flags, SourceFlag); err != nil {
+		return nil, err
+	}
+	apply(src.Flags, SourceFlag)
+
+	var settings = make(Settings, 0, len(Keys))
+	for _, key := range Keys {
+		setting, ok := values[key]
+		if !ok {
+			setting = &Setting{Key: key, Source: SourceUnset}
+		}
+		settings = append(settings, setting)
+	}
+	return settings, nil
+}
+
+/*
+readFile อ่านไฟล์ config ตามนามสกุล ไฟล์อื่นนอกจาก .yaml, .yml และ .toml ถือเป็น dotenv
+yaml และ toml เขียนได้ทั้งแบบ key ตรง (APP_PORT: 8080) และแบบซ้อน (app: {port: 8080})
+dotenv มักมีค่าอื่นปนอยู่จึงข้าม key ที่ไม่รู้จัก ส่วน yaml และ toml ถือเป็น error
+*/
+func readFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("load config file failed: %w", err)
+	}
+
+	var tree map[string]interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &tree)
+	case ".toml":
+		err = toml.Unmarshal(content, &tree)
+	default:
+		env, err := godotenv.Parse(bytes.NewReader(content))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return env, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	var values = make(map[string]string)
+	if err := flatten("", tree, values); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := checkKnownKeys(values, path); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+/* flatten แปลง key ซ้อนเป็นชื่อ env เช่น db.max_connections เป็น DB_MAX_CONNECTIONS */
+func flatten(prefix string, tree map[string]interface{}, out map[string]string) error {
+	for key, value := range tree {
+		name := strings.ToUpper(key)
+		if prefix != "" {
+			name = prefix + "_" + name
+		}
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if err := flatten(name, v, out); err != nil {
+				return err
+			}
+		case []interface{}:
+			return fmt.Errorf("%s: list is not supported", name)
+		case nil:
+			out[name] = ""
+		default:
+			out[name] = fmt.Sprint(v)
+		}
+	}
+	return nil
+}
+
+/* checkKnownKeys รายงาน key ที่สะกดผิดทั้งหมดในครั้งเดียว */
+func checkKnownKeys(values map[string]string, source string) error {
+	var unknown []*FieldError
+	for key := range values {
+		if !isKnownKey(key) {
+			unknown = append(unknown, &FieldError{Key: key, Message: fmt.Sprintf("unknown key in %s", source)})
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	sort.Slice(unknown, func(i, j int) bool {
+		return unknown[i].Key < unknown[j].Key
+	})
+	return &ValidationError{Fields: unknown}
+}
+
+func isKnownKey(key string) bool {
+	for _, k := range Keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestResolvePrecedence(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+app:
+  port: 8080
+  name: from-file
+db:
+  host: db.internal
+  max_connections: 10
+JWT_API_KEY: v2UeyF3xDtCMmNCG
+`)
+	settings, err := Resolve(Sources{
+		File:    path,
+		Environ: []string{"APP_NAME=from-env", "DB_MAX_CONNECTIONS=20", "PATH=/usr/bin"},
+		Flags:   map[string]string{"DB_MAX_CONNECTIONS": "30"},
+	})
+	assert.NoError(t, err)
+
+	var bySource = make(map[string]*Setting)
+	for _, setting := range settings {
+		bySource[setting.Key] = setting
+	}
+	assert.Len(t, settings, len(Keys))
+	assert.Equal(t, &Setting{Key: "APP_PORT", Value: "8080", Source: SourceFile}, bySource["APP_PORT"])
+	assert.Equal(t, &Setting{Key: "APP_NAME", Value: "from-env", Source: SourceEnv}, bySource["APP_NAME"])
+	assert.Equal(t, &Setting{Key: "DB_MAX_CONNECTIONS", Value: "30", Source: SourceFlag}, bySource["DB_MAX_CONNECTIONS"])
+	assert.Equal(t, &Setting{Key: "DB_SSL_MODE", Value: defaultSslMode, Source: SourceDefault}, bySource["DB_SSL_MODE"])
+	assert.Equal(t, SourceUnset, bySource["DB_PASSWORD"].Source)
+	assert.Equal(t, redactedValue, bySource["JWT_API_KEY"].Display(true))
+	assert.Equal(t, "v2UeyF3xDtCMmNCG", bySource["JWT_API_KEY"].Display(false))
+}
+
+func TestResolveFiles(t *testing.T) {
+	toml := writeFile(t, "config.toml", "[db]\nport = 5432\n")
+	settings, err := Resolve(Sources{File: toml})
+	assert.NoError(t, err)
+	assert.Equal(t, "5432", settings.Env()["DB_PORT"])
+
+	unknown := writeFile(t, "config.yml", "db:\n  hots: localhost\n")
+	_, err = Resolve(Sources{File: unknown})
+	assert.IsType(t, &ValidationError{}, err)
+
+	_, err = Resolve(Sources{File: filepath.Join(t.TempDir(), ".env")})
+	assert.Error(t, err)
+	_, err = Resolve(Sources{File: filepath.Join(t.TempDir(), ".env"), FileOptional: true})
+	assert.NoError(t, err)
+}
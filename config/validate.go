@@ -0,0 +1,204 @@
+package config
+
+import (
+	"fmt"
+	"github/pheethy/todo/constants"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	/* minSecretLength ความยาวขั้นต่ำของ JWT key เพื่อกันการตั้ง key สั้นจนเดาได้ */
+	minSecretLength = 16
+	maxPort         = 65535
+	maxConnections  = 1000
+)
+
+/* httpMethods method ที่ใช้ใน APP_ROUTE_TIMEOUTS ได้ */
+var httpMethods = map[string]bool{
+	http.MethodGet: true, http.MethodPost: true, http.MethodPut: true,
+	http.MethodPatch: true, http.MethodDelete: true, http.MethodHead: true, http.MethodOptions: true,
+}
+
+/* sslModes ค่า sslmode ที่ postgres รองรับ */
+var sslModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+/* FieldError ความผิดพลาดของ env หนึ่งตัว */
+type FieldError struct {
+	Key     string
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Key, e.Message)
+}
+
+/* ValidationError รวมความผิดพลาดของทุก field เพื่อให้แก้ config ได้ครบในรอบเดียว */
+type ValidationError struct {
+	Fields []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	var lines = make([]string, 0, len(e.Fields)+1)
+	lines = append(lines, constants.ERROR_CONFIG_INVALID)
+	for _, field := range e.Fields {
+		lines = append(lines, "  "+field.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+/*
+envReader อ่านค่าจาก env map พร้อมเก็บ error ไว้แทนการหยุดที่ field แรก
+ทุก method คืน zero value เมื่อค่าไม่ถูกต้อง ผู้เรียกต้องเช็ค err() ก่อนใช้ config
+*/
+type envReader struct {
+	env    map[string]string
+	fields []*FieldError
+}
+
+func newEnvReader(env map[string]string) *envReader {
+	return &envReader{env: env}
+}
+
+func (r *envReader) fail(key string, format string, args ...interface{}) {
+	r.fields = append(r.fields, &FieldError{Key: key, Message: fmt.Sprintf(format, args...)})
+}
+
+func (r *envReader) err() error {
+	if len(r.fields) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: r.fields}
+}
+
+/* required ค่าที่ต้องระบุเสมอ */
+func (r *envReader) required(key string) string {
+	value := strings.TrimSpace(r.env[key])
+	if value == "" {
+		r.fail(key, "is required")
+	}
+	return value
+}
+
+/* optional คืน def เมื่อไม่ได้ระบุ */
+func (r *envReader) optional(key string, def string) string {
+	value := strings.TrimSpace(r.env[key])
+	if value == "" {
+		return def
+	}
+	return value
+}
+
+/* oneOf ค่าที่ต้องอยู่ใน allowed คืน def เมื่อไม่ได้ระบุ */
+func (r *envReader) oneOf(key string, def string, allowed []string) string {
+	value := r.optional(key, def)
+	for _, a := range allowed {
+		if value == a {
+			return value
+		}
+	}
+	r.fail(key, "must be one of %s", strings.Join(allowed, ", "))
+	return ""
+}
+
+/* intRange จำนวนเต็มที่ต้องระบุและอยู่ในช่วง [min, max] */
+func (r *envReader) intRange(key string, min int, max int) int {
+	value := r.required(key)
+	if value == "" {
+		return 0
+	}
+	return r.parseInt(key, value, min, max)
+}
+
+/* optionalInt เหมือน intRange แต่คืน def เมื่อไม่ได้ระบุ */
+func (r *envReader) optionalInt(key string, def int, min int, max int) int {
+	value := strings.TrimSpace(r.env[key])
+	if value == "" {
+		return def
+	}
+	return r.parseInt(key, value, min, max)
+}
+
+func (r *envReader) parseInt(key string, value string, min int, max int) int {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		r.fail(key, "must be an integer, got %q", value)
+		return 0
+	}
+	if n < min || n > max {
+		r.fail(key, "must be between %d and %d, got %d", min, max, n)
+		return 0
+	}
+	return n
+}
+
+/* seconds ระยะเวลาหน่วยวินาทีที่ต้องระบุและมีค่าอย่างน้อย min */
+func (r *envReader) seconds(key string, min int) time.Duration {
+	return time.Duration(r.intRange(key, min, math.MaxInt32)) * time.Second
+}
+
+/* optionalSeconds เหมือน seconds แต่คืน def เมื่อไม่ได้ระบุ */
+func (r *envReader) optionalSeconds(key string, def time.Duration, min int) time.Duration {
+	if strings.TrimSpace(r.env[key]) == "" {
+		return def
+	}
+	return time.Duration(r.optionalInt(key, 0, min, math.MaxInt32)) * time.Second
+}
+
+/*
+routeTimeouts รายการ "METHOD /path=seconds" คั่นด้วย comma คืนค่าจาก def เมื่อไม่ได้ระบุ
+ระบุเป็น "-" เพื่อไม่ใช้ timeout เฉพาะ route เลย
+*/
+func (r *envReader) routeTimeouts(key string, def string) map[string]time.Duration {
+	var value = r.optional(key, def)
+	var timeouts = make(map[string]time.Duration)
+	if value == "-" {
+		return timeouts
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		route, seconds, ok := strings.Cut(entry, "=")
+		method, path, hasPath := strings.Cut(strings.TrimSpace(route), " ")
+		method, path = strings.ToUpper(method), strings.TrimSpace(path)
+		if !ok || !hasPath || !httpMethods[method] || !strings.HasPrefix(path, "/") {
+			r.fail(key, "entry %q must be METHOD /path=seconds", entry)
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(seconds))
+		if err != nil || n < 1 {
+			r.fail(key, "timeout of %s %s must be a positive integer, got %q", method, path, seconds)
+			continue
+		}
+		timeouts[method+" "+path] = time.Duration(n) * time.Second
+	}
+	return timeouts
+}
+
+/* boolean คืน def เมื่อไม่ได้ระบุ */
+func (r *envReader) boolean(key string, def bool) bool {
+	value := strings.TrimSpace(r.env[key])
+	if value == "" {
+		return def
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		r.fail(key, "must be true or false, got %q", value)
+	}
+	return b
+}
+
+/* secret ค่าลับที่ต้องระบุและยาวอย่างน้อย minLength ตัวอักษร ข้อความ error ไม่แสดงค่าจริง */
+func (r *envReader) secret(key string, minLength int) string {
+	value := r.required(key)
+	if value != "" && len(value) < minLength {
+		r.fail(key, "must be at least %d characters, got %d", minLength, len(value))
+	}
+	return value
+}
@@ -1,6 +1,117 @@
 package constants
 
 const (
-	ERROR_TASKNAME_WAS_DUPLICATE = "duplicate key value violates unique constraint \"todo_name_unique\""
+	ERROR_TASKNAME_WAS_DUPLICATE         = "duplicate key value violates unique constraint \"todo_name_unique\""
 	ERROR_TASKNAME_WAS_DUPLICATE_SERVICE = "task name was duplicate"
+	ERROR_TASK_NOT_FOUND                 = "task not found"
+	ERROR_TASK_ID_INVALID                = "task id is invalid"
+	ERROR_TASK_STATUS_WAS_CHANGED        = "task status was changed by another request"
+	ERROR_TASK_STATUS_INVALID            = "task status is invalid"
+	ERROR_TASK_SORT_INVALID              = "sort field or direction is invalid"
+	ERROR_TASK_FILTER_DATE_INVALID       = "date filter must be formatted as 2006-01-02 or 2006-01-02 15:04:05"
+	ERROR_TASK_CURSOR_INVALID            = "cursor is invalid"
+	ERROR_TASK_SEARCH_QUERY_REQUIRED     = "search query is required"
+	ERROR_TASK_IMPORT_EMPTY              = "tasks to import must not be empty"
+	ERROR_TASK_NAME_REQUIRED             = "task_name is required"
+	ERROR_ATTACHMENT_NOT_FOUND           = "attachment not found"
+	ERROR_ATTACHMENT_ID_INVALID          = "attachment id is invalid"
+)
+
+const (
+	ERROR_TOKEN_MISSING     = "authorization bearer token is required"
+	ERROR_TOKEN_INVALID     = "token is invalid"
+	ERROR_TOKEN_EXPIRED     = "token was expired"
+	ERROR_TOKEN_REVOKED     = "token was revoked"
+	ERROR_PERMISSION_DENIED = "permission denied"
+)
+
+const (
+	ERROR_USERNAME_WAS_DUPLICATE         = "duplicate key value violates unique constraint \"users_username_key\""
+	ERROR_USERNAME_WAS_DUPLICATE_SERVICE = "username was duplicate"
+	ERROR_EMAIL_WAS_DUPLICATE            = "duplicate key value violates unique constraint \"users_email_key\""
+	ERROR_EMAIL_WAS_DUPLICATE_SERVICE    = "email was duplicate"
+	ERROR_USER_NOT_FOUND                 = "user not found"
+	ERROR_USER_CREDENTIAL_INVALID        = "username or password is invalid"
+	ERROR_OAUTH_NOT_FOUND                = "oauth session not found"
+)
+
+const (
+	ERROR_API_KEY_NAME_WAS_DUPLICATE         = "duplicate key value violates unique constraint \"api_keys_name_key\""
+	ERROR_API_KEY_NAME_WAS_DUPLICATE_SERVICE = "api key name was duplicate"
+	ERROR_API_KEY_NOT_FOUND                  = "api key not found"
+	ERROR_API_KEY_ID_INVALID                 = "api key id is invalid"
+	ERROR_API_KEY_INVALID                    = "api key is invalid"
+	ERROR_API_KEY_REVOKED                    = "api key was revoked"
+	ERROR_API_KEY_SCOPE_INVALID              = "api key scope is invalid"
+)
+
+const (
+	ERROR_PRODUCT_NOT_FOUND                  = "product not found"
+	ERROR_PRODUCT_SORT_INVALID               = "sort field or direction is invalid"
+	ERROR_PRODUCT_PRICE_RANGE_INVALID        = "price range is invalid"
+	ERROR_PRODUCT_CATEGORY_NOT_FOUND         = "violates foreign key constraint \"products_categories_category_id_fkey\""
+	ERROR_PRODUCT_CATEGORY_NOT_FOUND_SERVICE = "category not found"
+)
+
+const (
+	ERROR_ORDER_NOT_FOUND          = "order not found"
+	ERROR_ORDER_STATUS_INVALID     = "order status is invalid"
+	ERROR_ORDER_STATUS_WAS_CHANGED = "order status was changed by another request"
+)
+
+const (
+	ERROR_FILE_REQUIRED     = "file is required"
+	ERROR_FILE_TOO_LARGE    = "file size exceeds the limit"
+	ERROR_FILE_TYPE_INVALID = "file type is not allowed"
+	ERROR_IMAGE_NOT_FOUND   = "image not found"
+)
+
+const (
+	ERROR_MIGRATION_SOURCE_INVALID    = "migration source is invalid"
+	ERROR_MIGRATION_VERSION_NOT_FOUND = "migration version not found"
+	ERROR_MIGRATION_STEPS_INVALID     = "migration steps must be greater than 0"
+	ERROR_MIGRATION_DIRTY             = "database is dirty, fix the failed migration and run migrate force <version>"
+)
+
+const (
+	ERROR_SEED_FIXTURES_NOT_FOUND = "fixtures not found"
+	ERROR_SEED_ROLE_INVALID       = "role must be customer or admin"
+)
+
+const (
+	ERROR_CONFIG_INVALID = "config is invalid"
+)
+
+const (
+	ERROR_REQUEST_BODY_TOO_LARGE = "request body exceeds the limit"
+	ERROR_REQUEST_TIMEOUT        = "request timed out"
+)
+
+/* roles */
+const (
+	ROLE_CUSTOMER = 1
+	ROLE_ADMIN    = 2
+	/* ROLE_MACHINE ใช้กับ machine client ที่เข้ามาด้วย api key ไม่มีในตาราง roles */
+	ROLE_MACHINE = 3
+)
+
+/* api key scopes */
+const (
+	API_KEY_SCOPE_TASKS_READ  = "tasks:read"
+	API_KEY_SCOPE_TASKS_WRITE = "tasks:write"
+)
+
+/* todo_status enum */
+const (
+	TASK_STATUS_DRAFT       = "draft"
+	TASK_STATUS_IN_PROGRESS = "in-progress"
+	TASK_STATUS_DONE        = "done"
+)
+
+/* order_status enum */
+const (
+	ORDER_STATUS_WAITING   = "waiting"
+	ORDER_STATUS_SHIPPING  = "shipping"
+	ORDER_STATUS_COMPLETED = "completed"
+	ORDER_STATUS_CANCELED  = "canceled"
 )
@@ -11,6 +11,7 @@ import (
 
 const (
 	TimestampLayout = "2006-01-02 15:04:05"
+	DateLayout      = "2006-01-02"
 )
 
 type Timestamp time.Time
@@ -33,6 +34,19 @@ func NewTimestampFromString(dateString string) Timestamp {
 	return Timestamp(d)
 }
 
+/* ParseTimestamp แปลง string ที่อยู่ในรูปแบบ TimestampLayout หรือ DateLayout เป็น Timestamp (Asia/Bangkok) */
+func ParseTimestamp(dateString string) (Timestamp, error) {
+	loc, _ := tz.LoadLocation("Asia/Bangkok")
+	d, err := time.ParseInLocation(TimestampLayout, dateString, loc)
+	if err != nil {
+		d, err = time.ParseInLocation(DateLayout, dateString, loc)
+		if err != nil {
+			return Timestamp{}, err
+		}
+	}
+	return Timestamp(d), nil
+}
+
 func NewTimestampFromTime(t time.Time) Timestamp {
 	loc := time.FixedZone("UTC+7", 7*60*60)
 	d, err := time.Parse(TimestampLayout, t.UTC().Format(TimestampLayout))
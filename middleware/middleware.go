@@ -0,0 +1,127 @@
+package middleware
+
+import (
+	"errors"
+	"github/pheethy/todo/auth"
+	"github/pheethy/todo/config"
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/service/apikeys"
+	"github/pheethy/todo/service/users"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+/* ApiKeyHeader header ที่ machine client ใช้ส่ง api key */
+const ApiKeyHeader = "X-API-Key"
+
+type Middleware struct {
+	cfg       config.Iconfig
+	usersUs   users.UsersUsecase
+	apiKeysUs apikeys.ApiKeysUsecase
+}
+
+func NewMiddleware(cfg config.Iconfig, usersUs users.UsersUsecase, apiKeysUs apikeys.ApiKeysUsecase) Middleware {
+	return Middleware{cfg: cfg, usersUs: usersUs, apiKeysUs: apiKeysUs}
+}
+
+/*
+JwtAuth ตรวจ bearer access token กับ session ใน oauth แล้วใส่ข้อมูลผู้ใช้ลงใน request context
+token ที่ sign out แล้วจะใช้ไม่ได้ทันทีแม้ยังไม่หมดอายุ
+*/
+func (m Middleware) JwtAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, err := bearerToken(c)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, err.Error())
+			return
+		}
+
+		user, err := m.usersUs.Authenticate(c.Request.Context(), token)
+		if err != nil {
+			switch err.Error() {
+			case constants.ERROR_TOKEN_INVALID, constants.ERROR_TOKEN_EXPIRED, constants.ERROR_TOKEN_REVOKED:
+				c.AbortWithStatusJSON(http.StatusUnauthorized, err.Error())
+			default:
+				c.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
+			}
+			return
+		}
+
+		c.Request = c.Request.WithContext(auth.WithUser(c.Request.Context(), user))
+		c.Next()
+	}
+}
+
+/*
+ApiKeyAuth ตรวจ api key จาก header X-API-Key ว่ายังใช้ได้และมี scope ที่ route ต้องการ
+แล้วใส่ machine client ลงใน request context ในฐานะผู้ใช้ role ROLE_MACHINE
+*/
+func (m Middleware) ApiKeyAuth(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(ApiKeyHeader)
+		if key == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, constants.ERROR_API_KEY_INVALID)
+			return
+		}
+
+		apiKey, err := m.apiKeysUs.Authenticate(c.Request.Context(), key)
+		if err != nil {
+			switch err.Error() {
+			case constants.ERROR_API_KEY_INVALID, constants.ERROR_API_KEY_REVOKED:
+				c.AbortWithStatusJSON(http.StatusUnauthorized, err.Error())
+			default:
+				c.AbortWithStatusJSON(http.StatusInternalServerError, err.Error())
+			}
+			return
+		}
+		if !apiKey.HasScope(scope) {
+			c.AbortWithStatusJSON(http.StatusForbidden, constants.ERROR_PERMISSION_DENIED)
+			return
+		}
+
+		c.Request = c.Request.WithContext(auth.WithUser(c.Request.Context(), apiKey.Claims()))
+		c.Next()
+	}
+}
+
+/* JwtOrApiKeyAuth ใช้ ApiKeyAuth เมื่อมี header X-API-Key นอกนั้นใช้ JwtAuth */
+func (m Middleware) JwtOrApiKeyAuth(scope string) gin.HandlerFunc {
+	jwtAuth := m.JwtAuth()
+	apiKeyAuth := m.ApiKeyAuth(scope)
+	return func(c *gin.Context) {
+		if c.GetHeader(ApiKeyHeader) != "" {
+			apiKeyAuth(c)
+			return
+		}
+		jwtAuth(c)
+	}
+}
+
+/* Authorize อนุญาตเฉพาะ role ที่กำหนด ต้องใช้ต่อจาก JwtAuth */
+func (m Middleware) Authorize(roles ...int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := auth.UserFromContext(c.Request.Context())
+		if user == nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, constants.ERROR_TOKEN_MISSING)
+			return
+		}
+		for _, role := range roles {
+			if user.RoleId == role {
+				c.Next()
+				return
+			}
+		}
+		c.AbortWithStatusJSON(http.StatusForbidden, constants.ERROR_PERMISSION_DENIED)
+	}
+}
+
+func bearerToken(c *gin.Context) (string, error) {
+	header := c.GetHeader("Authorization")
+	token := strings.TrimPrefix(header, "Bearer ")
+	if header == "" || token == header || token == "" {
+		return "", errors.New(constants.ERROR_TOKEN_MISSING)
+	}
+	return token, nil
+}
@@ -0,0 +1,240 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"github/pheethy/todo/auth"
+	"github/pheethy/todo/config"
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/models"
+	"github/pheethy/todo/service/apikeys/mocks"
+	"github/pheethy/todo/service/users"
+	usersMocks "github/pheethy/todo/service/users/mocks"
+	usersUsecase "github/pheethy/todo/service/users/usecase"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gofrs/uuid"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestConfig(t *testing.T) config.Iconfig {
+	env := `APP_PORT=8080
+APP_READ_TIMEOUT=60
+APP_WRTIE_TIMEOUT=60
+APP_BODY_LIMIT=10490000
+APP_FILE_LIMIT=2097000
+JWT_SECRET_KEY=6t7hkJmVr5U2L5WL
+JWT_ADMIN_KEY=76jfqJzzPJKhyKjk
+JWT_API_KEY=v2UeyF3xDtCMmNCG
+JWT_ACCESS_EXPIRES=60
+JWT_REFRESH_EXPIRES=120
+DB_HOST=127.0.0.1
+DB_PORT=5432
+DB_USERNAME=postgres
+DB_DATABASE=pheety_db_test
+DB_MAX_CONNECTIONS=1
+`
+	path := t.TempDir() + "/.env.test"
+	if err := os.WriteFile(path, []byte(env), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return config.LoadConfig(path)
+}
+
+/* newUsersUsecase usecase จริงที่ใช้ repository mock แทน database */
+func newUsersUsecase(t *testing.T, cfg config.Iconfig) (users.UsersUsecase, *usersMocks.UsersRepository) {
+	repo := usersMocks.NewUsersRepository(t)
+	return usersUsecase.NewUsersUsecase(cfg.Jwt(), repo), repo
+}
+
+/* signIn ออก access token ให้ user และให้ repository มี session ของ token นั้น */
+func signIn(t *testing.T, cfg config.Iconfig, repo *usersMocks.UsersRepository, user *models.UserClaims) string {
+	token, err := auth.NewAccessToken(cfg.Jwt(), user)
+	assert.NoError(t, err)
+	repo.On("FetchOauthByAccessToken", mock.Anything, token).Return(&models.Oauth{UserId: user.Id, AccessToken: token}, nil).Once()
+	repo.On("FetchUserById", mock.Anything, user.Id).Return(&models.User{Id: user.Id, Username: user.Username, RoleId: user.RoleId}, nil).Maybe()
+	return token
+}
+
+func TestJwtAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := newTestConfig(t)
+	user := &models.UserClaims{Id: "U000001", Username: "customer001", RoleId: 1}
+
+	var serve = func(usersUs users.UsersUsecase, header string) (*httptest.ResponseRecorder, *models.UserClaims) {
+		var caller *models.UserClaims
+		r := gin.New()
+		r.GET("/", NewMiddleware(cfg, usersUs, nil).JwtAuth(), func(c *gin.Context) {
+			caller = auth.UserFromContext(c.Request.Context())
+			c.Status(http.StatusOK)
+		})
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w, caller
+	}
+
+	t.Run("success", func(t *testing.T) {
+		usersUs, repo := newUsersUsecase(t, cfg)
+		token := signIn(t, cfg, repo, user)
+
+		w, caller := serve(usersUs, "Bearer "+token)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, user, caller)
+	})
+
+	t.Run("missing_token", func(t *testing.T) {
+		usersUs, _ := newUsersUsecase(t, cfg)
+		w, caller := serve(usersUs, "")
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Nil(t, caller)
+	})
+
+	t.Run("refresh_token_rejected", func(t *testing.T) {
+		usersUs, _ := newUsersUsecase(t, cfg)
+		token, err := auth.NewRefreshToken(cfg.Jwt(), user)
+		assert.NoError(t, err)
+
+		w, _ := serve(usersUs, "Bearer "+token)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("signed_out_token_rejected", func(t *testing.T) {
+		usersUs, repo := newUsersUsecase(t, cfg)
+		token := signIn(t, cfg, repo, user)
+		repo.On("DeleteOauth", mock.Anything, user.Id, token).Return(nil)
+		repo.On("FetchOauthByAccessToken", mock.Anything, token).Return(nil, errors.New(constants.ERROR_OAUTH_NOT_FOUND))
+
+		w, _ := serve(usersUs, "Bearer "+token)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		assert.NoError(t, usersUs.SignOut(context.Background(), user.Id, token))
+
+		w, caller := serve(usersUs, "Bearer "+token)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), constants.ERROR_TOKEN_REVOKED)
+		assert.Nil(t, caller)
+	})
+
+	t.Run("role_change_applies_immediately", func(t *testing.T) {
+		usersUs, repo := newUsersUsecase(t, cfg)
+		token, err := auth.NewAccessToken(cfg.Jwt(), user)
+		assert.NoError(t, err)
+		repo.On("FetchOauthByAccessToken", mock.Anything, token).Return(&models.Oauth{UserId: user.Id, AccessToken: token}, nil)
+		repo.On("FetchUserById", mock.Anything, user.Id).Return(&models.User{Id: user.Id, Username: user.Username, RoleId: constants.ROLE_ADMIN}, nil)
+
+		w, caller := serve(usersUs, "Bearer "+token)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, constants.ROLE_ADMIN, caller.RoleId)
+	})
+}
+
+func TestAuthorize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := newTestConfig(t)
+	customer := &models.UserClaims{Id: "U000001", Username: "customer001", RoleId: constants.ROLE_CUSTOMER}
+	admin := &models.UserClaims{Id: "U000002", Username: "admin001", RoleId: constants.ROLE_ADMIN}
+
+	var serve = func(user *models.UserClaims) int {
+		usersUs, repo := newUsersUsecase(t, cfg)
+		mid := NewMiddleware(cfg, usersUs, nil)
+		r := gin.New()
+		r.GET("/", mid.JwtAuth(), mid.Authorize(constants.ROLE_ADMIN), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+		token := signIn(t, cfg, repo, user)
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve(admin))
+	assert.Equal(t, http.StatusForbidden, serve(customer))
+
+	t.Run("admin_role_signed_with_secret_key_rejected", func(t *testing.T) {
+		/* ปลอม role admin โดยเซ็นด้วย secret key ของผู้ใช้ทั่วไป */
+		forged, err := auth.NewAccessToken(cfg.Jwt(), customer)
+		assert.NoError(t, err)
+		claims, err := auth.ParseToken(cfg.Jwt(), auth.Access, forged)
+		assert.NoError(t, err)
+		claims.Claims.RoleId = constants.ROLE_ADMIN
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(cfg.Jwt().SecretKey())
+		assert.NoError(t, err)
+
+		_, err = auth.ParseToken(cfg.Jwt(), auth.Access, token)
+		assert.EqualError(t, err, constants.ERROR_TOKEN_INVALID)
+	})
+}
+
+func TestJwtOrApiKeyAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := newTestConfig(t)
+	id := uuid.FromStringOrNil("3b0f3f25-5a43-4a6a-9d1c-0f1c1a4f3e11")
+	apiKey := &models.ApiKey{Id: &id, Name: "ci-bot", Scopes: constants.API_KEY_SCOPE_TASKS_READ}
+
+	var serve = func(apiKeysUs *mocks.ApiKeysUsecase, scope string, key string) (int, *models.UserClaims) {
+		var caller *models.UserClaims
+		r := gin.New()
+		r.GET("/", NewMiddleware(cfg, usersUsecase.NewUsersUsecase(cfg.Jwt(), nil), apiKeysUs).JwtOrApiKeyAuth(scope), func(c *gin.Context) {
+			caller = auth.UserFromContext(c.Request.Context())
+			c.Status(http.StatusOK)
+		})
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(ApiKeyHeader, key)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Code, caller
+	}
+
+	t.Run("success", func(t *testing.T) {
+		apiKeysUs := mocks.NewApiKeysUsecase(t)
+		apiKeysUs.On("Authenticate", mock.Anything, "todo_valid").Return(apiKey, nil)
+
+		code, caller := serve(apiKeysUs, constants.API_KEY_SCOPE_TASKS_READ, "todo_valid")
+
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, constants.ROLE_MACHINE, caller.RoleId)
+		assert.Equal(t, "apikey:ci-bot", caller.Username)
+	})
+
+	t.Run("missing_scope", func(t *testing.T) {
+		apiKeysUs := mocks.NewApiKeysUsecase(t)
+		apiKeysUs.On("Authenticate", mock.Anything, "todo_valid").Return(apiKey, nil)
+
+		code, caller := serve(apiKeysUs, constants.API_KEY_SCOPE_TASKS_WRITE, "todo_valid")
+
+		assert.Equal(t, http.StatusForbidden, code)
+		assert.Nil(t, caller)
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		apiKeysUs := mocks.NewApiKeysUsecase(t)
+		apiKeysUs.On("Authenticate", mock.Anything, "todo_revoked").Return(nil, errors.New(constants.ERROR_API_KEY_REVOKED))
+
+		code, _ := serve(apiKeysUs, constants.API_KEY_SCOPE_TASKS_READ, "todo_revoked")
+
+		assert.Equal(t, http.StatusUnauthorized, code)
+	})
+
+	t.Run("falls_back_to_jwt", func(t *testing.T) {
+		code, _ := serve(mocks.NewApiKeysUsecase(t), constants.API_KEY_SCOPE_TASKS_READ, "")
+
+		assert.Equal(t, http.StatusUnauthorized, code)
+	})
+}
@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github/pheethy/todo/constants"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+/* timeoutGrace เวลาเผื่อให้ Timeout เขียน response หลังหมดเวลาก่อน server ตัดการเชื่อมต่อ */
+const timeoutGrace = 5 * time.Second
+
+/*
+BodyLimit จำกัดขนาด request body ตาม APP_BODY_LIMIT
+request ที่ระบุ Content-Length เกินจะได้ 413 ทันที ส่วน body แบบ chunked จะถูกตัดเมื่ออ่านเกิน
+และ 400 ที่ handler ตอบกลับเพราะอ่าน body ไม่สำเร็จจะถูกเปลี่ยนเป็น 413
+*/
+func (m Middleware) BodyLimit() gin.HandlerFunc {
+	limit := int64(m.cfg.App().BodyLimit())
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > limit {
+			c.Header("Connection", "close")
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, constants.ERROR_REQUEST_BODY_TOO_LARGE)
+			return
+		}
+
+		body := &limitedBody{ReadCloser: http.MaxBytesReader(c.Writer, c.Request.Body, limit)}
+		c.Request.Body = body
+		c.Writer = &bodyLimitWriter{ResponseWriter: c.Writer, body: body}
+		c.Next()
+	}
+}
+
+/* limitedBody จำว่า handler อ่าน body เกิน limit หรือไม่ */
+type limitedBody struct {
+	io.ReadCloser
+	exceeded bool
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		b.exceeded = true
+	}
+	return n, err
+}
+
+type bodyLimitWriter struct {
+	gin.ResponseWriter
+	body *limitedBody
+}
+
+func (w *bodyLimitWriter) WriteHeader(code int) {
+	if w.body.exceeded && code == http.StatusBadRequest {
+		code = http.StatusRequestEntityTooLarge
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+/*
+Timeout กำหนด deadline ให้ request context ตาม APP_ROUTE_TIMEOUTS ของ route นั้น หรือ APP_WRTIE_TIMEOUT
+handler ที่ส่ง context ต่อให้ database จะถูกยกเลิกเมื่อหมดเวลา ถ้ายังไม่ได้ตอบกลับจะได้ 503
+และ 5xx ที่ handler ตอบหลังหมดเวลา (เช่น error "context deadline exceeded" จาก database) จะถูกเปลี่ยนเป็น 503 เช่นกัน
+*/
+func (m Middleware) Timeout() gin.HandlerFunc {
+	defaultTimeout := m.cfg.App().WriteTimeOut()
+	routeTimeouts := m.cfg.App().RouteTimeOuts()
+	return func(c *gin.Context) {
+		timeout, ok := routeTimeouts[c.Request.Method+" "+c.FullPath()]
+		if !ok {
+			timeout = defaultTimeout
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Writer = &timeoutWriter{ResponseWriter: c.Writer, ctx: ctx}
+		c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Writer.Written() {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, constants.ERROR_REQUEST_TIMEOUT)
+		}
+	}
+}
+
+/* timeoutWriter เปลี่ยน 5xx ที่เขียนหลัง deadline ของ request เป็น 503 พร้อม ERROR_REQUEST_TIMEOUT แทน body ของ handler */
+type timeoutWriter struct {
+	gin.ResponseWriter
+	ctx      context.Context
+	timedOut bool
+}
+
+func (w *timeoutWriter) WriteHeader(code int) {
+	if code >= http.StatusInternalServerError && errors.Is(w.ctx.Err(), context.DeadlineExceeded) {
+		w.timedOut = true
+		code = http.StatusServiceUnavailable
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *timeoutWriter) Write(data []byte) (int, error) {
+	if !w.timedOut {
+		return w.ResponseWriter.Write(data)
+	}
+	if !w.ResponseWriter.Written() {
+		body, _ := json.Marshal(constants.ERROR_REQUEST_TIMEOUT)
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if _, err := w.ResponseWriter.Write(body); err != nil {
+			return 0, err
+		}
+	}
+	return len(data), nil
+}
+
+func (w *timeoutWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+/* ServerWriteTimeout write timeout ของ http server ต้องยาวกว่า timeout ของทุก route เพื่อให้ตอบ 503 ได้ทัน */
+func ServerWriteTimeout(defaultTimeout time.Duration, routeTimeouts map[string]time.Duration) time.Duration {
+	var longest = defaultTimeout
+	for _, timeout := range routeTimeouts {
+		if timeout > longest {
+			longest = timeout
+		}
+	}
+	return longest + timeoutGrace
+}
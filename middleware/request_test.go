@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"context"
+	"github/pheethy/todo/config"
+	"github/pheethy/todo/constants"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubConfig struct {
+	config.Iconfig
+	app stubAppConfig
+}
+
+func (c stubConfig) App() config.IAppConfig {
+	return c.app
+}
+
+type stubAppConfig struct {
+	config.IAppConfig
+	bodyLimit     int
+	writeTimeout  time.Duration
+	routeTimeouts map[string]time.Duration
+}
+
+func (a stubAppConfig) BodyLimit() int {
+	return a.bodyLimit
+}
+func (a stubAppConfig) WriteTimeOut() time.Duration {
+	return a.writeTimeout
+}
+func (a stubAppConfig) RouteTimeOuts() map[string]time.Duration {
+	return a.routeTimeouts
+}
+
+func TestBodyLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mid := NewMiddleware(stubConfig{app: stubAppConfig{bodyLimit: 16}}, nil, nil)
+	r := gin.New()
+	r.Use(mid.BodyLimit())
+	r.POST("/echo", func(c *gin.Context) {
+		var body map[string]string
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		c.JSON(http.StatusOK, body)
+	})
+
+	cases := map[string]struct {
+		body          string
+		contentLength int64
+		status        int
+	}{
+		"within_limit":         {body: `{"a":"b"}`, contentLength: 9, status: http.StatusOK},
+		"content_length_over":  {body: `{"name":"too long body"}`, contentLength: 24, status: http.StatusRequestEntityTooLarge},
+		"chunked_body_over":    {body: `{"name":"too long body"}`, contentLength: -1, status: http.StatusRequestEntityTooLarge},
+		"invalid_json_in_size": {body: `{"a":`, contentLength: 5, status: http.StatusBadRequest},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/echo", io.NopCloser(strings.NewReader(tc.body)))
+			req.ContentLength = tc.contentLength
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+			assert.Equal(t, tc.status, rec.Code)
+		})
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mid := NewMiddleware(stubConfig{app: stubAppConfig{
+		writeTimeout:  time.Second,
+		routeTimeouts: map[string]time.Duration{"GET /slow/:id": 10 * time.Millisecond, "GET /slow/:id/error": 10 * time.Millisecond},
+	}}, nil, nil)
+	r := gin.New()
+	r.Use(mid.Timeout())
+	var deadlines = make(map[string]time.Duration)
+	wait := func(c *gin.Context) {
+		deadline, _ := c.Request.Context().Deadline()
+		deadlines[c.FullPath()] = time.Until(deadline)
+		select {
+		case <-c.Request.Context().Done():
+		case <-time.After(50 * time.Millisecond):
+			c.JSON(http.StatusOK, "done")
+		}
+	}
+	r.GET("/slow/:id", wait)
+	r.GET("/fast", wait)
+	/* handler ส่วนใหญ่ตอบ 500 พร้อม error ของ context เองเมื่อ database ถูกยกเลิก */
+	r.GET("/slow/:id/error", func(c *gin.Context) {
+		<-c.Request.Context().Done()
+		c.JSON(http.StatusInternalServerError, c.Request.Context().Err().Error())
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/slow/1", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), constants.ERROR_REQUEST_TIMEOUT)
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/slow/1/error", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), constants.ERROR_REQUEST_TIMEOUT)
+	assert.NotContains(t, rec.Body.String(), context.DeadlineExceeded.Error())
+
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/fast", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Greater(t, deadlines["/fast"], 500*time.Millisecond)
+
+	assert.Equal(t, 15*time.Second, ServerWriteTimeout(time.Second, map[string]time.Duration{"POST /a": 10 * time.Second}))
+}
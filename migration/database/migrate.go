@@ -0,0 +1,326 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"github/pheethy/todo/constants"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/BlackMocca/sqlx"
+)
+
+const (
+	MIGRATION_SOURCE_TASK        = "postgres_task"
+	MIGRATION_SOURCE_KAWAII_SHOP = "postgres_kawaii_shop"
+
+	/*
+		schemaMigrationsPrefix แต่ละ source มีตาราง version ของตัวเอง (schema_migrations_<source>) เพราะ app ใช้ทั้งสองชุดใน database เดียวกัน
+		โครงสร้างตารางเหมือน golang-migrate ใช้ CLI ของ golang-migrate ได้ด้วย x-migrations-table
+	*/
+	schemaMigrationsPrefix = "schema_migrations_"
+
+	/* migrationLockKey key ของ pg_advisory_lock กันหลาย instance migrate พร้อมกันตอน auto migrate */
+	migrationLockKey = 7_100_542_021
+)
+
+/* migrationFiles ไฟล์ sql ทุกชุดถูก embed ไว้ใน binary ไม่ต้อง copy โฟลเดอร์ migration ไปพร้อมกับ binary */
+//go:embed postgres_task/*.sql postgres_kawaii_shop/*.sql
+var migrationFiles embed.FS
+
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+/* Migration ไฟล์ migration หนึ่ง version ตามรูปแบบชื่อไฟล์ของ golang-migrate: 000001_name.up.sql */
+type Migration struct {
+	Version uint   `json:"version"`
+	Name    string `json:"name"`
+	up      string
+	down    string
+}
+
+/* MigrationStatus Version เป็น 0 เมื่อยังไม่เคย migrate, Dirty แปลว่า migration ล่าสุดรันไม่สำเร็จ */
+type MigrationStatus struct {
+	Version uint         `json:"version"`
+	Dirty   bool         `json:"dirty"`
+	Applied []*Migration `json:"applied"`
+	Pending []*Migration `json:"pending"`
+}
+
+type Migrator interface {
+	Up(ctx context.Context) error
+	Down(ctx context.Context, steps int) error
+	Goto(ctx context.Context, version uint) error
+	Status(ctx context.Context) (*MigrationStatus, error)
+	Force(ctx context.Context, version uint) error
+}
+
+type migrator struct {
+	db         *sqlx.DB
+	table      string
+	migrations []*Migration
+}
+
+/* NewMigrator source คือชุด migration ที่จะใช้ เช่น MIGRATION_SOURCE_TASK */
+func NewMigrator(db *sqlx.DB, source string) (Migrator, error) {
+	migrations, err := LoadMigrations(source)
+	if err != nil {
+		return nil, err
+	}
+	return migrator{db: db, table: schemaMigrationsPrefix + source, migrations: migrations}, nil
+}
+
+/* LoadMigrations อ่านไฟล์ migration ของ source จากไฟล์ที่ embed ไว้ เรียงตาม version */
+func LoadMigrations(source string) ([]*Migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, source)
+	if err != nil || source == "" {
+		return nil, errors.New(constants.ERROR_MIGRATION_SOURCE_INVALID)
+	}
+
+	var byVersion = make(map[uint]*Migration)
+	for _, entry := range entries {
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		content, err := migrationFiles.ReadFile(path.Join(source, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[uint(version)]
+		if !ok {
+			migration = &Migration{Version: uint(version), Name: match[2]}
+			byVersion[uint(version)] = migration
+		}
+		if match[3] == "up" {
+			migration.up = string(content)
+		} else {
+			migration.down = string(content)
+		}
+	}
+
+	var migrations = make([]*Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+func (m migrator) Up(ctx context.Context) error {
+	if len(m.migrations) == 0 {
+		return nil
+	}
+	return m.Goto(ctx, m.migrations[len(m.migrations)-1].Version)
+}
+
+/* Down ย้อน migration กลับไป steps version จาก version ปัจจุบัน */
+func (m migrator) Down(ctx context.Context, steps int) error {
+	if steps < 1 {
+		return errors.New(constants.ERROR_MIGRATION_STEPS_INVALID)
+	}
+	return m.withLock(ctx, func(conn *sqlx.Conn) error {
+		current, err := m.currentVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		index := m.indexOf(current)
+		target := uint(0)
+		if index-steps >= 0 {
+			target = m.migrations[index-steps].Version
+		}
+		return m.migrate(ctx, conn, current, target)
+	})
+}
+
+/* Goto migrate ขึ้นหรือลงไปจนถึง version ที่ระบุ version 0 คือย้อนทุก migration */
+func (m migrator) Goto(ctx context.Context, version uint) error {
+	if version != 0 && m.indexOf(version) < 0 {
+		return errors.New(constants.ERROR_MIGRATION_VERSION_NOT_FOUND)
+	}
+	return m.withLock(ctx, func(conn *sqlx.Conn) error {
+		current, err := m.currentVersion(ctx, conn)
+		if err != nil {
+			return err
+		}
+		return m.migrate(ctx, conn, current, version)
+	})
+}
+
+func (m migrator) Status(ctx context.Context) (*MigrationStatus, error) {
+	var status = &MigrationStatus{
+		Applied: make([]*Migration, 0),
+		Pending: make([]*Migration, 0),
+	}
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		var err error
+		status.Version, status.Dirty, err = m.readVersion(ctx, conn)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, migration := range m.migrations {
+		if migration.Version <= status.Version {
+			status.Applied = append(status.Applied, migration)
+		} else {
+			status.Pending = append(status.Pending, migration)
+		}
+	}
+
+	return status, nil
+}
+
+/* Force ตั้ง version โดยไม่รัน sql และล้างสถานะ dirty ใช้หลังแก้ database ที่ migrate ค้างไว้ด้วยมือ */
+func (m migrator) Force(ctx context.Context, version uint) error {
+	if version != 0 && m.indexOf(version) < 0 {
+		return errors.New(constants.ERROR_MIGRATION_VERSION_NOT_FOUND)
+	}
+	return m.withLock(ctx, func(conn *sqlx.Conn) error {
+		return m.setVersion(ctx, conn, version, false)
+	})
+}
+
+/*
+migrate รันไฟล์ up ของ version ที่มากกว่า current จนถึง target หรือไฟล์ down ของ version ที่มากกว่า target ย้อนลงมา
+ไฟล์ sql จัดการ transaction เอง (BEGIN/COMMIT) จึงบันทึก version เป็น dirty ไว้ก่อนรันแต่ละไฟล์
+ถ้าไฟล์ใดล้มเหลว database จะค้างเป็น dirty ที่ version นั้นจนกว่าจะสั่ง force
+*/
+func (m migrator) migrate(ctx context.Context, conn *sqlx.Conn, current uint, target uint) error {
+	if target >= current {
+		for _, migration := range m.migrations {
+			if migration.Version <= current || migration.Version > target {
+				continue
+			}
+			if err := m.apply(ctx, conn, migration, migration.up, migration.Version, migration.Version); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+		if migration.Version > current || migration.Version <= target {
+			continue
+		}
+		if migration.down == "" {
+			return fmt.Errorf("migration %d_%s has no down file", migration.Version, migration.Name)
+		}
+		previous := uint(0)
+		if i > 0 {
+			previous = m.migrations[i-1].Version
+		}
+		if err := m.apply(ctx, conn, migration, migration.down, migration.Version, previous); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m migrator) apply(ctx context.Context, conn *sqlx.Conn, migration *Migration, query string, dirtyVersion uint, version uint) error {
+	if err := m.setVersion(ctx, conn, dirtyVersion, true); err != nil {
+		return err
+	}
+	if _, err := conn.ExecContext(ctx, query); err != nil {
+		/* ไฟล์ที่ล้มเหลวกลางคันทิ้ง transaction ที่ abort ไว้ใน connection ต้อง rollback ก่อนใช้ต่อ */
+		conn.ExecContext(ctx, "ROLLBACK")
+		return fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+	}
+	return m.setVersion(ctx, conn, version, false)
+}
+
+/* withLock ทุกคำสั่งใช้ connection เดียวกันเพราะ pg_advisory_lock ผูกกับ session */
+func (m migrator) withLock(ctx context.Context, fn func(conn *sqlx.Conn) error) error {
+	conn, err := m.db.Connx(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationLockKey); err != nil {
+		return err
+	}
+	defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockKey)
+
+	createSql := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)`, m.table)
+	if _, err := conn.ExecContext(ctx, createSql); err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+func (m migrator) readVersion(ctx context.Context, conn *sqlx.Conn) (uint, bool, error) {
+	var version uint
+	var dirty bool
+	err := conn.QueryRowxContext(ctx, fmt.Sprintf(`SELECT version, dirty FROM %s LIMIT 1`, m.table)).Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	return version, dirty, err
+}
+
+/*
+currentVersion ไม่ยอม migrate ต่อถ้า migration ก่อนหน้าค้างเป็น dirty
+หรือ version ใน database ไม่มีในชุด migration (เช่น binary เก่ากว่า database)
+*/
+func (m migrator) currentVersion(ctx context.Context, conn *sqlx.Conn) (uint, error) {
+	version, dirty, err := m.readVersion(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+	if dirty {
+		return 0, errors.New(constants.ERROR_MIGRATION_DIRTY)
+	}
+	if version != 0 && m.indexOf(version) < 0 {
+		return 0, errors.New(constants.ERROR_MIGRATION_VERSION_NOT_FOUND)
+	}
+	return version, nil
+}
+
+func (m migrator) setVersion(ctx context.Context, conn *sqlx.Conn, version uint, dirty bool) error {
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %s`, m.table)); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if version > 0 {
+		insertSql := fmt.Sprintf(`INSERT INTO %s (version, dirty) VALUES ($1::bigint, $2::boolean)`, m.table)
+		if _, err := tx.ExecContext(ctx, insertSql, version, dirty); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+/* indexOf คืน -1 เมื่อไม่มี version นี้ในชุด migration */
+func (m migrator) indexOf(version uint) int {
+	for i, migration := range m.migrations {
+		if migration.Version == version {
+			return i
+		}
+	}
+	return -1
+}
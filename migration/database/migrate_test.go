@@ -0,0 +1,80 @@
+package database
+
+import (
+	"context"
+	"github/pheethy/todo/constants"
+	"testing"
+
+	"github.com/BlackMocca/sqlx"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/DATA-DOG/go-sqlmock.v2"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	for _, source := range []string{MIGRATION_SOURCE_TASK, MIGRATION_SOURCE_KAWAII_SHOP} {
+		t.Run(source, func(t *testing.T) {
+			migrations, err := LoadMigrations(source)
+
+			assert.NoError(t, err)
+			assert.NotEmpty(t, migrations)
+			for i, migration := range migrations {
+				assert.Equal(t, uint(i+1), migration.Version)
+				assert.NotEmpty(t, migration.up)
+				assert.NotEmpty(t, migration.down)
+			}
+		})
+	}
+
+	t.Run("invalid_source", func(t *testing.T) {
+		for _, source := range []string{"", "postgres_unknown", "../database"} {
+			_, err := LoadMigrations(source)
+			assert.EqualError(t, err, constants.ERROR_MIGRATION_SOURCE_INVALID)
+		}
+	})
+}
+
+func TestMigratorUp(t *testing.T) {
+	var newMigrator = func(t *testing.T) (Migrator, sqlmock.Sqlmock) {
+		db, sqlMock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		t.Cleanup(func() { db.Close() })
+
+		migrator, err := NewMigrator(sqlx.NewDb(db, "sqlmock"), MIGRATION_SOURCE_TASK)
+		assert.NoError(t, err)
+
+		sqlMock.ExpectExec(`SELECT pg_advisory_lock`).WillReturnResult(sqlmock.NewResult(0, 0))
+		sqlMock.ExpectExec(`CREATE TABLE IF NOT EXISTS schema_migrations_postgres_task`).WillReturnResult(sqlmock.NewResult(0, 0))
+		return migrator, sqlMock
+	}
+	var expectSetVersion = func(sqlMock sqlmock.Sqlmock, version int, dirty bool) {
+		sqlMock.ExpectBegin()
+		sqlMock.ExpectExec(`DELETE FROM schema_migrations_postgres_task`).WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectExec(`INSERT INTO schema_migrations_postgres_task`).WithArgs(version, dirty).WillReturnResult(sqlmock.NewResult(0, 1))
+		sqlMock.ExpectCommit()
+	}
+
+	t.Run("apply_pending", func(t *testing.T) {
+		migrator, sqlMock := newMigrator(t)
+		sqlMock.ExpectQuery(`SELECT version, dirty FROM schema_migrations_postgres_task`).
+			WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(5, false))
+		expectSetVersion(sqlMock, 6, true)
+		sqlMock.ExpectExec(`DROP CONSTRAINT IF EXISTS todo_name_unique`).WillReturnResult(sqlmock.NewResult(0, 0))
+		expectSetVersion(sqlMock, 6, false)
+		sqlMock.ExpectExec(`SELECT pg_advisory_unlock`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.NoError(t, migrator.Up(context.Background()))
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("dirty", func(t *testing.T) {
+		migrator, sqlMock := newMigrator(t)
+		sqlMock.ExpectQuery(`SELECT version, dirty FROM schema_migrations_postgres_task`).
+			WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(3, true))
+		sqlMock.ExpectExec(`SELECT pg_advisory_unlock`).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		assert.EqualError(t, migrator.Up(context.Background()), constants.ERROR_MIGRATION_DIRTY)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,23 @@
+-- Create transaction --
+BEGIN;
+
+-- ข้อมูลตัวอย่างจาก 000002 ย้ายไปอยู่ใน seed/fixtures ใช้คำสั่ง seed --fixtures dev แทน --
+-- ลบเฉพาะแถวที่ 000002 สร้างไว้ roles และ categories ยังเป็นข้อมูลตั้งต้นของระบบ --
+DELETE FROM "products_orders" WHERE "order_id" IN ('O000001', 'O000002');
+DELETE FROM "orders" WHERE "id" IN ('O000001', 'O000002');
+
+DELETE FROM "products_categories" WHERE "product_id" IN ('P000001', 'P000002', 'P000003', 'P000004', 'P000005', 'P000006');
+DELETE FROM "images" WHERE "product_id" IN ('P000001', 'P000002', 'P000003', 'P000004', 'P000005', 'P000006');
+DELETE FROM "products" WHERE "id" IN ('P000001', 'P000002', 'P000003', 'P000004', 'P000005', 'P000006');
+
+-- ไม่ลบ user ที่มี order อื่นอยู่แล้ว --
+DELETE FROM "oauth" WHERE "user_id" IN (
+    SELECT "id" FROM "users"
+    WHERE "username" IN ('customer001', 'admin001')
+    AND NOT EXISTS (SELECT 1 FROM "orders" WHERE "orders"."user_id" = "users"."id")
+);
+DELETE FROM "users"
+WHERE "username" IN ('customer001', 'admin001')
+AND NOT EXISTS (SELECT 1 FROM "orders" WHERE "orders"."user_id" = "users"."id");
+
+COMMIT;
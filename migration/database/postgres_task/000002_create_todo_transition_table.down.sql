@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS todo_transition_todo_id_idx;
+DROP TABLE todo_transition;
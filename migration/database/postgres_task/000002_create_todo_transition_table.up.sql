@@ -0,0 +1,19 @@
+-- Create transaction --
+BEGIN;
+
+-- set time zone --
+SET TIME ZONE 'Asia/Bangkok';
+
+CREATE TABLE "todo_transition" (
+  "id" uuid NOT NULL UNIQUE PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "todo_id" uuid NOT NULL,
+  "from_status" todo_status NOT NULL,
+  "to_status" todo_status NOT NULL,
+  "moved_by" VARCHAR(255) NOT NULL,
+  "moved_at" TIMESTAMP NOT NULL DEFAULT now(),
+  CONSTRAINT fk_todo_transition_todo FOREIGN KEY ("todo_id") REFERENCES "todo" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX todo_transition_todo_id_idx ON todo_transition (todo_id);
+
+COMMIT;
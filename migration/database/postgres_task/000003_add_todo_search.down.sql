@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS todo_task_name_trgm_idx;
+DROP INDEX IF EXISTS todo_search_vector_idx;
+ALTER TABLE todo
+DROP COLUMN IF EXISTS search_vector;
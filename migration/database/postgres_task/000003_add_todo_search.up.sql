@@ -0,0 +1,13 @@
+-- Create transaction --
+BEGIN;
+
+-- install Extension trigram --
+CREATE EXTENSION IF NOT EXISTS "pg_trgm";
+
+ALTER TABLE todo
+ADD COLUMN "search_vector" tsvector GENERATED ALWAYS AS (to_tsvector('simple', coalesce(task_name, ''))) STORED;
+
+CREATE INDEX todo_search_vector_idx ON todo USING GIN (search_vector);
+CREATE INDEX todo_task_name_trgm_idx ON todo USING GIN (task_name gin_trgm_ops);
+
+COMMIT;
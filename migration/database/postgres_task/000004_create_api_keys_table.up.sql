@@ -0,0 +1,21 @@
+-- Create transaction --
+BEGIN;
+
+-- set time zone --
+SET TIME ZONE 'Asia/Bangkok';
+
+-- key จริงแสดงครั้งเดียวตอนสร้าง เก็บเฉพาะ HMAC-SHA256 ของ key (JWT_API_KEY เป็น secret) --
+CREATE TABLE "api_keys" (
+  "id" uuid NOT NULL UNIQUE PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "name" VARCHAR(255) UNIQUE NOT NULL,
+  "prefix" VARCHAR(16) NOT NULL,
+  "key_hash" VARCHAR(64) UNIQUE NOT NULL,
+  "scopes" VARCHAR NOT NULL DEFAULT '',
+  "created_by" VARCHAR(255) NOT NULL,
+  "last_used_at" TIMESTAMP NULL,
+  "revoked_at" TIMESTAMP NULL,
+  "created_at" TIMESTAMP NOT NULL DEFAULT now(),
+  "updated_at" TIMESTAMP NOT NULL DEFAULT now()
+);
+
+COMMIT;
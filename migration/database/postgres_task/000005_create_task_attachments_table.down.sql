@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS task_attachments_todo_id_idx;
+DROP TABLE IF EXISTS task_attachments;
@@ -0,0 +1,23 @@
+-- Create transaction --
+BEGIN;
+
+-- set time zone --
+SET TIME ZONE 'Asia/Bangkok';
+
+-- ไฟล์จริงอยู่ใน storage ตาม storage_key, checksum คือ sha256 ของไฟล์ (hex) --
+CREATE TABLE "task_attachments" (
+  "id" uuid NOT NULL UNIQUE PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "todo_id" uuid NOT NULL,
+  "filename" VARCHAR(255) NOT NULL,
+  "content_type" VARCHAR(255) NOT NULL,
+  "size" BIGINT NOT NULL,
+  "checksum" VARCHAR(64) NOT NULL,
+  "storage_key" VARCHAR NOT NULL,
+  "uploaded_by" VARCHAR(255) NOT NULL,
+  "created_at" TIMESTAMP NOT NULL DEFAULT now(),
+  CONSTRAINT fk_task_attachments_todo FOREIGN KEY ("todo_id") REFERENCES "todo" ("id") ON DELETE CASCADE
+);
+
+CREATE INDEX task_attachments_todo_id_idx ON task_attachments (todo_id);
+
+COMMIT;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS todo_name_unique;
+ALTER TABLE todo
+ADD CONSTRAINT TODO_NAME_UNIQUE UNIQUE (task_name);
@@ -0,0 +1,11 @@
+-- Create transaction --
+BEGIN;
+
+-- ชื่อ task ต้องไม่ซ้ำเฉพาะ task ที่ยังไม่ถูกลบ task ในถังขยะจึงไม่กันการสร้างชื่อเดิม --
+-- ใช้ชื่อเดิมเพื่อให้ error ของ postgres ยังตรงกับ constants.ERROR_TASKNAME_WAS_DUPLICATE --
+ALTER TABLE todo
+DROP CONSTRAINT IF EXISTS todo_name_unique;
+
+CREATE UNIQUE INDEX todo_name_unique ON todo (task_name) WHERE deleted_at IS NULL;
+
+COMMIT;
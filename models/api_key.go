@@ -0,0 +1,85 @@
+package models
+
+import (
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/helper"
+	"strings"
+
+	"github.com/gofrs/uuid"
+)
+
+/* ApiKeyScopes scope ทั้งหมดที่ออกให้ api key ได้ */
+var ApiKeyScopes = []string{
+	constants.API_KEY_SCOPE_TASKS_READ,
+	constants.API_KEY_SCOPE_TASKS_WRITE,
+}
+
+/* ApiKey scopes เก็บเป็นสตริงคั่นด้วยช่องว่างแบบ OAuth เช่น "tasks:read tasks:write" */
+type ApiKey struct {
+	TableName  struct{}          `json:"-" db:"api_keys" pk:"Id"`
+	Id         *uuid.UUID        `json:"id" db:"id" type:"uuid"`
+	Name       string            `json:"name" db:"name" type:"string"`
+	Prefix     string            `json:"prefix" db:"prefix" type:"string"`
+	KeyHash    string            `json:"-" db:"key_hash" type:"string"`
+	Scopes     string            `json:"scopes" db:"scopes" type:"string"`
+	CreatedBy  string            `json:"created_by" db:"created_by" type:"string"`
+	LastUsedAt *helper.Timestamp `json:"last_used_at" db:"last_used_at" type:"timestamp"`
+	RevokedAt  *helper.Timestamp `json:"revoked_at" db:"revoked_at" type:"timestamp"`
+	CreatedAt  *helper.Timestamp `json:"created_at" db:"created_at" type:"timestamp"`
+	UpdatedAt  *helper.Timestamp `json:"updated_at" db:"updated_at" type:"timestamp"`
+}
+
+func IsValidApiKeyScope(scope string) bool {
+	for _, s := range ApiKeyScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func (k *ApiKey) NewId() {
+	uid, _ := uuid.NewV4()
+	k.Id = &uid
+}
+
+func (k *ApiKey) SetCreatedAt(now helper.Timestamp) {
+	k.CreatedAt = &now
+}
+
+func (k *ApiKey) SetUpatedAt(now helper.Timestamp) {
+	k.UpdatedAt = &now
+}
+
+func (k *ApiKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+func (k *ApiKey) HasScope(scope string) bool {
+	for _, s := range strings.Fields(k.Scopes) {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+/* Claims machine client ถูกมองเป็นผู้ใช้ role ROLE_MACHINE ชื่อ "apikey:<name>" */
+func (k *ApiKey) Claims() *UserClaims {
+	return &UserClaims{
+		Id:       k.Id.String(),
+		Username: "apikey:" + k.Name,
+		RoleId:   constants.ROLE_MACHINE,
+	}
+}
+
+type ApiKeyCreate struct {
+	Name   string   `json:"name" binding:"required"`
+	Scopes []string `json:"scopes" binding:"required,min=1"`
+}
+
+/* ApiKeyIssued ผลลัพธ์ตอนสร้าง key ค่า Key จะไม่ถูกแสดงอีก */
+type ApiKeyIssued struct {
+	*ApiKey
+	Key string `json:"key"`
+}
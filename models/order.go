@@ -0,0 +1,87 @@
+package models
+
+import (
+	"github/pheethy/todo/helper"
+
+	"github.com/gofrs/uuid"
+)
+
+/* Order column jsonb (transfer_slip, products_orders.product) ถูก orm decode ผ่าน type:"jsonb" */
+type Order struct {
+	TableName    struct{}          `json:"-" db:"orders" pk:"Id"`
+	Id           string            `json:"id" db:"id" type:"string"`
+	UserId       string            `json:"user_id" db:"user_id" type:"string"`
+	Contact      string            `json:"contact" db:"contact" type:"string"`
+	Address      string            `json:"address" db:"address" type:"string"`
+	TransferSlip *TransferSlip     `json:"transfer_slip" db:"transfer_slip" type:"jsonb"`
+	Status       OrderStatus       `json:"status" db:"status" type:"order_status"`
+	CreatedAt    *helper.Timestamp `json:"created_at" db:"created_at" type:"timestamp"`
+	UpdatedAt    *helper.Timestamp `json:"updated_at" db:"updated_at" type:"timestamp"`
+
+	Products []*ProductsOrder `json:"products" db:"-" fk:"fk_field1:Id,fk_field2:OrderId"`
+}
+
+func IsValidOrderStatus(status OrderStatus) bool {
+	for _, value := range OrderStatus("").EnumValues() {
+		if string(status) == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *Order) SetCreatedAt(now helper.Timestamp) {
+	o.CreatedAt = &now
+}
+
+func (o *Order) SetUpatedAt(now helper.Timestamp) {
+	o.UpdatedAt = &now
+}
+
+/* TotalPrice ราคารวมจาก product ที่ snapshot ไว้ตอนสั่งซื้อ */
+func (o *Order) TotalPrice() float64 {
+	var total float64
+	for _, item := range o.Products {
+		if item.Product != nil {
+			total += item.Product.Price * float64(item.Qty)
+		}
+	}
+	return total
+}
+
+type TransferSlip struct {
+	Id        string `json:"id"`
+	Filename  string `json:"filename"`
+	Url       string `json:"url"`
+	CreatedAt string `json:"created_at"`
+}
+
+/* ProductsOrder Product คือ snapshot ของ product ตอนสั่งซื้อ ราคาที่แก้ทีหลังจึงไม่กระทบ order เดิม */
+type ProductsOrder struct {
+	TableName struct{}   `json:"-" db:"products_orders" pk:"Id"`
+	Id        *uuid.UUID `json:"id" db:"id" type:"uuid"`
+	OrderId   string     `json:"-" db:"order_id" type:"string"`
+	Qty       int        `json:"qty" db:"qty" type:"int32"`
+	Product   *Product   `json:"product" db:"product" type:"jsonb"`
+}
+
+func (p *ProductsOrder) NewId() {
+	uid, _ := uuid.NewV4()
+	p.Id = &uid
+}
+
+type OrderPlace struct {
+	Contact  string               `json:"contact" binding:"required"`
+	Address  string               `json:"address" binding:"required"`
+	Products []*OrderPlaceProduct `json:"products" binding:"required,min=1,dive"`
+}
+
+type OrderPlaceProduct struct {
+	ProductId string `json:"product_id" binding:"required"`
+	Qty       int    `json:"qty" binding:"required,min=1"`
+}
+
+type OrderFilter struct {
+	UserId string
+	Status OrderStatus
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/orm"
+)
+
+/* TaskStatus ค่าของ enum todo_status ใช้กับ tag type:"todo_status" */
+type TaskStatus string
+
+func (TaskStatus) EnumValues() []string {
+	return []string{constants.TASK_STATUS_DRAFT, constants.TASK_STATUS_IN_PROGRESS, constants.TASK_STATUS_DONE}
+}
+
+/* OrderStatus ค่าของ enum order_status ใช้กับ tag type:"order_status" */
+type OrderStatus string
+
+func (OrderStatus) EnumValues() []string {
+	return []string{constants.ORDER_STATUS_WAITING, constants.ORDER_STATUS_SHIPPING, constants.ORDER_STATUS_COMPLETED, constants.ORDER_STATUS_CANCELED}
+}
+
+/* ลงทะเบียน enum ของ postgres ให้ orm ตรวจค่าของ column ก่อน map ลง model */
+func init() {
+	for _, registry := range []orm.Registry{
+		orm.NewEnumType("todo_status", TaskStatus("")),
+		orm.NewEnumType("order_status", OrderStatus("")),
+	} {
+		if err := orm.RegisterType(registry); err != nil {
+			panic(err)
+		}
+	}
+}
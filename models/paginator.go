@@ -0,0 +1,40 @@
+package models
+
+const (
+	DEFAULT_PAGE     = 1
+	DEFAULT_PER_PAGE = 20
+	MAX_PER_PAGE     = 100
+)
+
+type Paginator struct {
+	Page       int `json:"page"`
+	PerPage    int `json:"per_page"`
+	TotalRows  int `json:"total_rows"`
+	TotalPages int `json:"total_pages"`
+}
+
+func NewPaginator(page int, perPage int) *Paginator {
+	if page < 1 {
+		page = DEFAULT_PAGE
+	}
+	if perPage < 1 {
+		perPage = DEFAULT_PER_PAGE
+	}
+	if perPage > MAX_PER_PAGE {
+		perPage = MAX_PER_PAGE
+	}
+	return &Paginator{Page: page, PerPage: perPage}
+}
+
+func (p *Paginator) Offset() int {
+	return (p.Page - 1) * p.PerPage
+}
+
+/* SetTotalRows รับค่าจาก column total_row (orm.Mapper.GetPaginateTotal) แล้วคำนวณจำนวนหน้า */
+func (p *Paginator) SetTotalRows(total int) {
+	p.TotalRows = total
+	p.TotalPages = 0
+	if total > 0 {
+		p.TotalPages = (total + p.PerPage - 1) / p.PerPage
+	}
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"github/pheethy/todo/helper"
+
+	"github.com/gofrs/uuid"
+)
+
+/*
+Product ใช้ orm autobinding ผูก Categories และ Images เข้ากับ product ผ่าน ProductId
+column ของแต่ละ model ต้อง select เป็น "products.*", "categories.*" และ "images.*"
+*/
+type Product struct {
+	TableName   struct{}          `json:"-" db:"products" pk:"Id"`
+	Id          string            `json:"id" db:"id" type:"string"`
+	Title       string            `json:"title" db:"title" type:"string"`
+	Description string            `json:"description" db:"description" type:"string"`
+	Price       float64           `json:"price" db:"price" type:"float64"`
+	CreatedAt   *helper.Timestamp `json:"created_at" db:"created_at" type:"timestamp"`
+	UpdatedAt   *helper.Timestamp `json:"updated_at" db:"updated_at" type:"timestamp"`
+
+	Categories []*Category `json:"categories" db:"-" fk:"fk_field1:Id,fk_field2:ProductId"`
+	Images     []*Image    `json:"images" db:"-" fk:"fk_field1:Id,fk_field2:ProductId"`
+}
+
+func (p *Product) SetCreatedAt(now helper.Timestamp) {
+	p.CreatedAt = &now
+}
+
+func (p *Product) SetUpatedAt(now helper.Timestamp) {
+	p.UpdatedAt = &now
+}
+
+/* CategoryIds id ของ category ที่ผูกกับ product */
+func (p *Product) CategoryIds() []int {
+	var ids = make([]int, 0, len(p.Categories))
+	for _, category := range p.Categories {
+		ids = append(ids, category.Id)
+	}
+	return ids
+}
+
+/* Category ProductId มาจาก products_categories ใช้ผูกกับ product เท่านั้น */
+type Category struct {
+	TableName struct{} `json:"-" db:"categories" pk:"Id"`
+	Id        int      `json:"id" db:"id" type:"int32"`
+	Title     string   `json:"title" db:"title" type:"string"`
+	ProductId string   `json:"-" db:"product_id" type:"string"`
+}
+
+type Image struct {
+	TableName struct{}          `json:"-" db:"images" pk:"Id"`
+	Id        *uuid.UUID        `json:"id" db:"id" type:"uuid"`
+	Filename  string            `json:"filename" db:"filename" type:"string"`
+	Url       string            `json:"url" db:"url" type:"string"`
+	ProductId string            `json:"-" db:"product_id" type:"string"`
+	CreatedAt *helper.Timestamp `json:"created_at" db:"created_at" type:"timestamp"`
+	UpdatedAt *helper.Timestamp `json:"updated_at" db:"updated_at" type:"timestamp"`
+}
+
+func (i *Image) NewId() {
+	uid, _ := uuid.NewV4()
+	i.Id = &uid
+}
+
+func (i *Image) SetCreatedAt(now helper.Timestamp) {
+	i.CreatedAt = &now
+}
+
+func (i *Image) SetUpatedAt(now helper.Timestamp) {
+	i.UpdatedAt = &now
+}
+
+type ProductCreate struct {
+	Title       string  `json:"title" binding:"required"`
+	Description string  `json:"description"`
+	Price       float64 `json:"price" binding:"min=0"`
+	CategoryIds []int   `json:"category_ids"`
+}
+
+/* ProductUpdate field ที่เป็น nil จะไม่ถูกแก้ไข ส่ง category_ids มาเมื่อต้องการแทนที่ category ทั้งหมด */
+type ProductUpdate struct {
+	Title       *string  `json:"title"`
+	Description *string  `json:"description"`
+	Price       *float64 `json:"price" binding:"omitempty,min=0"`
+	CategoryIds *[]int   `json:"category_ids"`
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"errors"
+	"github/pheethy/todo/constants"
+	"strings"
+)
+
+/* ProductSortFields คือ column ที่อนุญาตให้ sort ได้ใน GET /products */
+var ProductSortFields = []string{"created_at", "title", "price"}
+
+type ProductFilter struct {
+	CategoryId    int
+	MinPrice      *float64
+	MaxPrice      *float64
+	SortBy        string
+	SortDirection string
+}
+
+func NewProductFilter() *ProductFilter {
+	return &ProductFilter{
+		SortBy:        "created_at",
+		SortDirection: "desc",
+	}
+}
+
+func (f *ProductFilter) Validate() error {
+	if (f.MinPrice != nil && *f.MinPrice < 0) || (f.MaxPrice != nil && *f.MaxPrice < 0) {
+		return errors.New(constants.ERROR_PRODUCT_PRICE_RANGE_INVALID)
+	}
+	if f.MinPrice != nil && f.MaxPrice != nil && *f.MinPrice > *f.MaxPrice {
+		return errors.New(constants.ERROR_PRODUCT_PRICE_RANGE_INVALID)
+	}
+
+	var sortable bool
+	for _, field := range ProductSortFields {
+		if field == f.SortBy {
+			sortable = true
+			break
+		}
+	}
+	if !sortable {
+		return errors.New(constants.ERROR_PRODUCT_SORT_INVALID)
+	}
+
+	f.SortDirection = strings.ToLower(f.SortDirection)
+	if f.SortDirection != "asc" && f.SortDirection != "desc" {
+		return errors.New(constants.ERROR_PRODUCT_SORT_INVALID)
+	}
+
+	return nil
+}
@@ -10,13 +10,22 @@ type Task struct {
 	TableName   struct{}          `json:"-" db:"todo" pk:"Id"`
 	Id          *uuid.UUID        `json:"id" db:"id" type:"uuid"`
 	TaskName    string            `json:"task_name" db:"task_name" type:"string"`
-	Status      string            `json:"status" db:"status" type:"string"`
+	Status      TaskStatus        `json:"status" db:"status" type:"todo_status"`
 	CreatorName string            `json:"creator_name" db:"creator_name" type:"string"`
 	CreatedAt   *helper.Timestamp `json:"created_at" db:"created_at" type:"timestamp"`
 	DeletedAt   *helper.Timestamp `json:"deleted_at" db:"deleted_at" type:"timestamp"`
 	UpdatedAt   *helper.Timestamp `json:"updated_at" db:"updated_at" type:"timestamp"`
 }
 
+func IsValidTaskStatus(status TaskStatus) bool {
+	for _, value := range TaskStatus("").EnumValues() {
+		if string(status) == value {
+			return true
+		}
+	}
+	return false
+}
+
 func (t *Task) NewId() {
 	uid, _ := uuid.NewV4()
 	t.Id = &uid
@@ -29,3 +38,8 @@ func (t *Task) SetCreatedAt(now helper.Timestamp) {
 func (t *Task) SetUpatedAt(now helper.Timestamp) {
 	t.UpdatedAt = &now
 }
+
+/* TaskUpdate field ที่เป็น nil จะไม่ถูกแก้ไข (PATCH) ส่วน PUT ต้องส่ง task_name มาเสมอ */
+type TaskUpdate struct {
+	TaskName *string `json:"task_name"`
+}
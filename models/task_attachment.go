@@ -0,0 +1,30 @@
+package models
+
+import (
+	"github/pheethy/todo/helper"
+
+	"github.com/gofrs/uuid"
+)
+
+/* TaskAttachment ไฟล์แนบของ task ตัวไฟล์อยู่ใน storage ที่ StorageKey */
+type TaskAttachment struct {
+	TableName   struct{}          `json:"-" db:"task_attachments" pk:"Id"`
+	Id          *uuid.UUID        `json:"id" db:"id" type:"uuid"`
+	TaskId      *uuid.UUID        `json:"task_id" db:"todo_id" type:"uuid"`
+	Filename    string            `json:"filename" db:"filename" type:"string"`
+	ContentType string            `json:"content_type" db:"content_type" type:"string"`
+	Size        int64             `json:"size" db:"size" type:"int64"`
+	Checksum    string            `json:"checksum" db:"checksum" type:"string"`
+	StorageKey  string            `json:"-" db:"storage_key" type:"string"`
+	UploadedBy  string            `json:"uploaded_by" db:"uploaded_by" type:"string"`
+	CreatedAt   *helper.Timestamp `json:"created_at" db:"created_at" type:"timestamp"`
+}
+
+func (a *TaskAttachment) NewId() {
+	uid, _ := uuid.NewV4()
+	a.Id = &uid
+}
+
+func (a *TaskAttachment) SetCreatedAt(now helper.Timestamp) {
+	a.CreatedAt = &now
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github/pheethy/todo/constants"
+
+	"github.com/gofrs/uuid"
+)
+
+/* cursorTimeLayout เก็บ created_at ละเอียดถึง microsecond ให้ตรงกับ column TIMESTAMP ของ postgres */
+const cursorTimeLayout = "2006-01-02 15:04:05.999999"
+
+/* TaskCursor ตำแหน่งของ task ใน keyset pagination (เรียงตาม created_at, id) */
+type TaskCursor struct {
+	CreatedAt string    `json:"c"`
+	Id        uuid.UUID `json:"i"`
+	Prev      bool      `json:"p,omitempty"`
+}
+
+func NewTaskCursor(task *Task, prev bool) *TaskCursor {
+	var cursor = &TaskCursor{Prev: prev}
+	if task.Id != nil {
+		cursor.Id = *task.Id
+	}
+	if task.CreatedAt != nil {
+		cursor.CreatedAt = task.CreatedAt.Format(cursorTimeLayout)
+	}
+	return cursor
+}
+
+func (c TaskCursor) Encode() string {
+	bu, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(bu)
+}
+
+func DecodeTaskCursor(token string) (*TaskCursor, error) {
+	bu, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.New(constants.ERROR_TASK_CURSOR_INVALID)
+	}
+	var cursor = new(TaskCursor)
+	if err := json.Unmarshal(bu, cursor); err != nil || cursor.CreatedAt == "" || cursor.Id == uuid.Nil {
+		return nil, errors.New(constants.ERROR_TASK_CURSOR_INVALID)
+	}
+	return cursor, nil
+}
+
+type CursorPaginator struct {
+	PerPage    int         `json:"per_page"`
+	NextCursor string      `json:"next_cursor"`
+	PrevCursor string      `json:"prev_cursor"`
+	Cursor     *TaskCursor `json:"-"`
+}
+
+func NewCursorPaginator(cursor *TaskCursor, perPage int) *CursorPaginator {
+	if perPage < 1 {
+		perPage = DEFAULT_PER_PAGE
+	}
+	if perPage > MAX_PER_PAGE {
+		perPage = MAX_PER_PAGE
+	}
+	return &CursorPaginator{PerPage: perPage, Cursor: cursor}
+}
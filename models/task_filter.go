@@ -0,0 +1,53 @@
+package models
+
+import (
+	"errors"
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/helper"
+	"strings"
+)
+
+/* TaskSortFields คือ column ที่อนุญาตให้ sort ได้ใน GET /tasks */
+var TaskSortFields = []string{"created_at", "updated_at", "task_name", "status"}
+
+type TaskFilter struct {
+	Status        TaskStatus
+	CreatorName   string
+	CreatedFrom   *helper.Timestamp
+	CreatedTo     *helper.Timestamp
+	UpdatedFrom   *helper.Timestamp
+	UpdatedTo     *helper.Timestamp
+	SortBy        string
+	SortDirection string
+}
+
+func NewTaskFilter() *TaskFilter {
+	return &TaskFilter{
+		SortBy:        "created_at",
+		SortDirection: "desc",
+	}
+}
+
+func (f *TaskFilter) Validate() error {
+	if f.Status != "" && !IsValidTaskStatus(f.Status) {
+		return errors.New(constants.ERROR_TASK_STATUS_INVALID)
+	}
+
+	var sortable bool
+	for _, field := range TaskSortFields {
+		if field == f.SortBy {
+			sortable = true
+			break
+		}
+	}
+	if !sortable {
+		return errors.New(constants.ERROR_TASK_SORT_INVALID)
+	}
+
+	f.SortDirection = strings.ToLower(f.SortDirection)
+	if f.SortDirection != "asc" && f.SortDirection != "desc" {
+		return errors.New(constants.ERROR_TASK_SORT_INVALID)
+	}
+
+	return nil
+}
@@ -0,0 +1,16 @@
+package models
+
+import "github.com/gofrs/uuid"
+
+/*
+TaskSearchResult ผลลัพธ์ของการค้นหา task
+ใช้ orm autobinding จับคู่ column "search.*" กับ "todo.*" ที่ select ด้วย orm.GetSelector(new(Task))
+*/
+type TaskSearchResult struct {
+	TableName struct{}   `json:"-" db:"search" pk:"Id"`
+	Id        *uuid.UUID `json:"-" db:"id" type:"uuid"`
+	Rank      float64    `json:"rank" db:"rank" type:"float64"`
+	Highlight string     `json:"highlight" db:"-"`
+
+	Task *Task `json:"task" db:"-" fk:"fk_field1:Id,fk_field2:Id"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"github/pheethy/todo/helper"
+
+	"github.com/gofrs/uuid"
+)
+
+type TaskTransition struct {
+	TableName  struct{}          `json:"-" db:"todo_transition" pk:"Id"`
+	Id         *uuid.UUID        `json:"id" db:"id" type:"uuid"`
+	TaskId     *uuid.UUID        `json:"task_id" db:"todo_id" type:"uuid"`
+	FromStatus TaskStatus        `json:"from_status" db:"from_status" type:"todo_status"`
+	ToStatus   TaskStatus        `json:"to_status" db:"to_status" type:"todo_status"`
+	MovedBy    string            `json:"moved_by" db:"moved_by" type:"string"`
+	MovedAt    *helper.Timestamp `json:"moved_at" db:"moved_at" type:"timestamp"`
+}
+
+func (t *TaskTransition) NewId() {
+	uid, _ := uuid.NewV4()
+	t.Id = &uid
+}
+
+func (t *TaskTransition) SetMovedAt(now helper.Timestamp) {
+	t.MovedAt = &now
+}
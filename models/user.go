@@ -0,0 +1,74 @@
+package models
+
+import (
+	"github/pheethy/todo/helper"
+
+	"github.com/gofrs/uuid"
+)
+
+type User struct {
+	TableName struct{}          `json:"-" db:"users" pk:"Id"`
+	Id        string            `json:"id" db:"id" type:"string"`
+	Username  string            `json:"username" db:"username" type:"string"`
+	Email     string            `json:"email" db:"email" type:"string"`
+	Password  string            `json:"-" db:"password" type:"string"`
+	RoleId    int               `json:"role_id" db:"role_id" type:"int32"`
+	CreatedAt *helper.Timestamp `json:"created_at" db:"created_at" type:"timestamp"`
+	UpdatedAt *helper.Timestamp `json:"updated_at" db:"updated_at" type:"timestamp"`
+}
+
+func (u *User) SetCreatedAt(now helper.Timestamp) {
+	u.CreatedAt = &now
+}
+
+func (u *User) SetUpatedAt(now helper.Timestamp) {
+	u.UpdatedAt = &now
+}
+
+func (u *User) Claims() *UserClaims {
+	return &UserClaims{
+		Id:       u.Id,
+		Username: u.Username,
+		RoleId:   u.RoleId,
+	}
+}
+
+type UserSignUp struct {
+	Username string `json:"username" binding:"required"`
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required,min=8"`
+}
+
+type UserSignIn struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+type Oauth struct {
+	TableName    struct{}          `json:"-" db:"oauth" pk:"Id"`
+	Id           *uuid.UUID        `json:"id" db:"id" type:"uuid"`
+	UserId       string            `json:"user_id" db:"user_id" type:"string"`
+	AccessToken  string            `json:"access_token" db:"access_token" type:"string"`
+	RefreshToken string            `json:"refresh_token" db:"refresh_token" type:"string"`
+	CreatedAt    *helper.Timestamp `json:"created_at" db:"created_at" type:"timestamp"`
+	UpdatedAt    *helper.Timestamp `json:"updated_at" db:"updated_at" type:"timestamp"`
+}
+
+func (o *Oauth) NewId() {
+	uid, _ := uuid.NewV4()
+	o.Id = &uid
+}
+
+func (o *Oauth) SetCreatedAt(now helper.Timestamp) {
+	o.CreatedAt = &now
+}
+
+func (o *Oauth) SetUpatedAt(now helper.Timestamp) {
+	o.UpdatedAt = &now
+}
+
+/* UserPassport ผลลัพธ์ของการ sign-in / refresh */
+type UserPassport struct {
+	User  *User  `json:"user"`
+	Token *Oauth `json:"token"`
+}
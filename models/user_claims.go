@@ -0,0 +1,8 @@
+package models
+
+/* UserClaims ข้อมูลของผู้ใช้ที่ฝังอยู่ใน jwt token */
+type UserClaims struct {
+	Id       string `json:"id"`
+	Username string `json:"username"`
+	RoleId   int    `json:"role"`
+}
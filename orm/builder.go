@@ -0,0 +1,409 @@
+package orm
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/fatih/structs"
+)
+
+/*
+Caster registry ที่ระบุ type ของ postgres ให้ builder ใส่ cast หลัง placeholder เช่น $1::todo_status
+registry ที่ไม่ได้ implement จะใช้ type ตามชื่อใน pgTypes หรือไม่ cast เลยถ้าไม่มีชื่อนั้น
+*/
+type Caster interface {
+	PgType() string
+}
+
+/* Valuer registry ที่ต้องแปลงค่าของ field ก่อนส่งเป็น argument เช่น json ที่ต้อง marshal ก่อน */
+type Valuer interface {
+	Value(val interface{}) (interface{}, error)
+}
+
+/* pgTypes type ของ postgres ของ registry พื้นฐาน */
+var pgTypes = map[string]string{
+	"uuid":       "uuid",
+	"zerouuid":   "uuid",
+	"string":     "text",
+	"zerostring": "text",
+	"int32":      "int",
+	"int64":      "bigint",
+	"zeroint":    "bigint",
+	"float32":    "real",
+	"float64":    "float8",
+	"zerofloat":  "float8",
+	"timestamp":  "timestamp",
+	"date":       "date",
+	"bool":       "boolean",
+	"zerobool":   "boolean",
+}
+
+type WriteOption struct {
+	skipZero   bool
+	columns    []string
+	returning  []string
+	conditions []string
+	conflict   []string
+	registry   *TypeRegistry
+}
+
+func NewWriteOption() WriteOption {
+	return WriteOption{}
+}
+
+/* SetSkipZero ไม่เขียน field ที่เป็น zero value (nil, "", 0) ใช้ไม่ได้กับ InsertMany เพราะทุกแถวต้องมี column เดียวกัน */
+func (o WriteOption) SetSkipZero() WriteOption {
+	o.skipZero = true
+	return o
+}
+
+/* SetColumns เขียนเฉพาะ column ที่ระบุ สำหรับ Update คือ column ใน SET */
+func (o WriteOption) SetColumns(columns ...string) WriteOption {
+	o.columns = columns
+	return o
+}
+
+/* SetReturning column ที่ database สร้างให้ เช่น id หรือ created_at ผู้เรียกต้อง scan เอง */
+func (o WriteOption) SetReturning(columns ...string) WriteOption {
+	o.returning = columns
+	return o
+}
+
+/* SetConditions เงื่อนไขเพิ่มเติมต่อท้าย WHERE pk ของ Update เช่น deleted_at IS NULL */
+func (o WriteOption) SetConditions(conditions ...string) WriteOption {
+	o.conditions = conditions
+	return o
+}
+
+/* SetConflict column ของ ON CONFLICT ใน Upsert ค่าเริ่มต้นคือ pk */
+func (o WriteOption) SetConflict(columns ...string) WriteOption {
+	o.conflict = columns
+	return o
+}
+
+/* SetRegistry ใช้ type ใน registry ก่อน GlobalRegistry เหมือน MapperOption.SetRegistry */
+func (o WriteOption) SetRegistry(registry *TypeRegistry) WriteOption {
+	o.registry = registry
+	return o
+}
+
+func (o WriteOption) lookupType(typeName string) (Registry, bool) {
+	return MapperOption{registry: o.registry}.lookupType(typeName)
+}
+
+/* Insert สร้าง INSERT ของ model หนึ่งแถว */
+func Insert(model interface{}, option WriteOption) (string, []interface{}, error) {
+	_, _, sql, args, err := insert(model, option)
+	if err != nil {
+		return "", nil, err
+	}
+	return sql + returning(option), args, nil
+}
+
+func insert(model interface{}, option WriteOption) (*writeTable, []*writeColumn, string, []interface{}, error) {
+	table, err := newWriteTable(model, option)
+	if err != nil {
+		return nil, nil, "", nil, err
+	}
+	columns, err := table.insertColumns(option.skipZero)
+	if err != nil {
+		return nil, nil, "", nil, err
+	}
+
+	var args = make([]interface{}, 0, len(columns))
+	values, err := table.placeholders(columns, &args)
+	if err != nil {
+		return nil, nil, "", nil, err
+	}
+
+	sql := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table.name, joinColumns(columns), values)
+	return table, columns, sql, args, nil
+}
+
+/* InsertMany สร้าง INSERT หลายแถวในคำสั่งเดียว models เป็น slice ของ model ที่มีอย่างน้อยหนึ่งแถว */
+func InsertMany(models interface{}, option WriteOption) (string, []interface{}, error) {
+	rv := reflect.ValueOf(models)
+	if rv.Kind() != reflect.Slice {
+		return "", nil, ErrMustBeSlice
+	}
+	if rv.Len() == 0 {
+		return "", nil, ErrEmptyData
+	}
+
+	first, err := newWriteTable(rv.Index(0).Interface(), option)
+	if err != nil {
+		return "", nil, err
+	}
+	columns, err := first.insertColumns(false)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var rows = make([]string, 0, rv.Len())
+	var args = make([]interface{}, 0, rv.Len()*len(columns))
+	for i := 0; i < rv.Len(); i++ {
+		table, err := newWriteTable(rv.Index(i).Interface(), option)
+		if err != nil {
+			return "", nil, err
+		}
+		if table.name != first.name {
+			return "", nil, ErrMustBeSameTable
+		}
+		values, err := table.placeholders(columns, &args)
+		if err != nil {
+			return "", nil, err
+		}
+		rows = append(rows, "("+values+")")
+	}
+
+	sql := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", first.name, joinColumns(columns), strings.Join(rows, ", "))
+	return sql + returning(option), args, nil
+}
+
+/* Update สร้าง UPDATE ... WHERE pk ของ model column ใน SET คือทุก column ที่ไม่ใช่ pk หรือตาม SetColumns */
+func Update(model interface{}, option WriteOption) (string, []interface{}, error) {
+	table, err := newWriteTable(model, option)
+	if err != nil {
+		return "", nil, err
+	}
+	columns, err := table.updateColumns(option.skipZero)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var args = make([]interface{}, 0, len(columns)+len(table.pk))
+	var sets = make([]string, 0, len(columns))
+	for _, column := range columns {
+		placeholder, err := table.placeholder(column, &args)
+		if err != nil {
+			return "", nil, err
+		}
+		sets = append(sets, column.name+" = "+placeholder)
+	}
+
+	var conds = make([]string, 0, len(table.pk)+len(option.conditions))
+	for _, column := range table.pk {
+		placeholder, err := table.placeholder(column, &args)
+		if err != nil {
+			return "", nil, err
+		}
+		conds = append(conds, column.name+" = "+placeholder)
+	}
+	conds = append(conds, option.conditions...)
+
+	sql := fmt.Sprintf("UPDATE %s SET %s WHERE %s", table.name, strings.Join(sets, ", "), strings.Join(conds, " AND "))
+	return sql + returning(option), args, nil
+}
+
+/*
+Upsert สร้าง INSERT ... ON CONFLICT (conflict) DO UPDATE SET ของทุก column ที่ insert ยกเว้น column ที่ชน
+ถ้าไม่มี column ให้ update จะใช้ DO NOTHING
+*/
+func Upsert(model interface{}, option WriteOption) (string, []interface{}, error) {
+	table, columns, sql, args, err := insert(model, option)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var conflict = option.conflict
+	if len(conflict) == 0 {
+		for _, column := range table.pk {
+			conflict = append(conflict, column.name)
+		}
+	}
+	var sets = make([]string, 0, len(columns))
+	for _, column := range columns {
+		if !containsString(conflict, column.name) {
+			sets = append(sets, fmt.Sprintf("%s = EXCLUDED.%s", column.name, column.name))
+		}
+	}
+
+	sql += fmt.Sprintf(" ON CONFLICT (%s)", strings.Join(conflict, ", "))
+	if len(sets) == 0 {
+		sql += " DO NOTHING"
+	} else {
+		sql += " DO UPDATE SET " + strings.Join(sets, ", ")
+	}
+	return sql + returning(option), args, nil
+}
+
+type writeColumn struct {
+	name     string
+	field    *structs.Field
+	typeName string
+}
+
+type writeTable struct {
+	name    string
+	columns []*writeColumn
+	pk      []*writeColumn
+	option  WriteOption
+}
+
+func newWriteTable(model interface{}, option WriteOption) (*writeTable, error) {
+	if isNil(model) {
+		return nil, ErrMustNotNil
+	}
+	if err := mustbeStruct(reflect.Indirect(reflect.ValueOf(model)).Interface()); err != nil {
+		return nil, err
+	}
+
+	faith := structs.New(model)
+	var table = &writeTable{name: getTableName(faith), option: option}
+	if table.name == "" {
+		return nil, fmt.Errorf("error: %s %w", TABLE_FIELD_NAME, ErrTagValueNotFound)
+	}
+
+	var byField = make(map[string]*writeColumn)
+	for _, field := range faith.Fields() {
+		name := field.Tag(TAGNAME)
+		if field.Name() == TABLE_FIELD_NAME || name == "" || name == "-" {
+			continue
+		}
+		column := &writeColumn{name: name, field: field, typeName: field.Tag(TAG_TYPE)}
+		table.columns = append(table.columns, column)
+		byField[field.Name()] = column
+	}
+
+	for _, fieldName := range strings.Split(getTagValue(faith, TABLE_FIELD_NAME, TAG_PK), fieldSeperate) {
+		column, ok := byField[strings.TrimSpace(fieldName)]
+		if !ok {
+			return nil, fmt.Errorf("error: pk %s %w", fieldName, ErrFieldNotFound)
+		}
+		table.pk = append(table.pk, column)
+	}
+	return table, nil
+}
+
+func (t *writeTable) insertColumns(skipZero bool) ([]*writeColumn, error) {
+	return t.pickColumns(t.columns, skipZero)
+}
+
+func (t *writeTable) updateColumns(skipZero bool) ([]*writeColumn, error) {
+	var columns = make([]*writeColumn, 0, len(t.columns))
+	for _, column := range t.columns {
+		if !t.isPk(column) {
+			columns = append(columns, column)
+		}
+	}
+	return t.pickColumns(columns, skipZero)
+}
+
+/* pickColumns กรอง column ตาม SetColumns และ SetSkipZero column ใน SetColumns ที่ไม่มีใน model ถือเป็น error */
+func (t *writeTable) pickColumns(columns []*writeColumn, skipZero bool) ([]*writeColumn, error) {
+	for _, name := range t.option.columns {
+		if !t.hasColumn(name) {
+			return nil, fmt.Errorf("error: column %s %w", name, ErrFieldNotFound)
+		}
+	}
+
+	var picked = make([]*writeColumn, 0, len(columns))
+	for _, column := range columns {
+		if len(t.option.columns) > 0 && !containsString(t.option.columns, column.name) {
+			continue
+		}
+		if skipZero && column.field.IsZero() {
+			continue
+		}
+		picked = append(picked, column)
+	}
+	if len(picked) == 0 {
+		return nil, ErrNoColumns
+	}
+	return picked, nil
+}
+
+func (t *writeTable) placeholders(columns []*writeColumn, args *[]interface{}) (string, error) {
+	var values = make([]string, 0, len(columns))
+	for _, column := range columns {
+		/* InsertMany ใช้ column ของแถวแรก ต้องหา field ของแถวนี้จากชื่อ column */
+		own := t.column(column.name)
+		if own == nil {
+			return "", fmt.Errorf("error: column %s %w", column.name, ErrFieldNotFound)
+		}
+		placeholder, err := t.placeholder(own, args)
+		if err != nil {
+			return "", err
+		}
+		values = append(values, placeholder)
+	}
+	return strings.Join(values, ", "), nil
+}
+
+/* placeholder เพิ่มค่าของ column ลงใน args แล้วคืน $n พร้อม cast ตาม type ของ column */
+func (t *writeTable) placeholder(column *writeColumn, args *[]interface{}) (string, error) {
+	value := column.field.Value()
+	var cast string
+	if column.typeName != "" && column.typeName != "-" {
+		registry, ok := t.option.lookupType(column.typeName)
+		if !ok {
+			return "", fmt.Errorf("error: %s %w", column.typeName, ErrRegistryNotFound)
+		}
+		if valuer, ok := registry.(Valuer); ok {
+			converted, err := valuer.Value(value)
+			if err != nil {
+				return "", fmt.Errorf("error: %s %w", column.field.Name(), err)
+			}
+			value = converted
+		}
+		if caster, ok := registry.(Caster); ok {
+			cast = caster.PgType()
+		} else {
+			cast = pgTypes[column.typeName]
+		}
+	}
+
+	*args = append(*args, value)
+	placeholder := fmt.Sprintf("$%d", len(*args))
+	if cast != "" {
+		placeholder += "::" + cast
+	}
+	return placeholder, nil
+}
+
+func (t *writeTable) column(name string) *writeColumn {
+	for _, column := range t.columns {
+		if column.name == name {
+			return column
+		}
+	}
+	return nil
+}
+
+func (t *writeTable) hasColumn(name string) bool {
+	return t.column(name) != nil
+}
+
+func (t *writeTable) isPk(column *writeColumn) bool {
+	for _, pk := range t.pk {
+		if pk == column {
+			return true
+		}
+	}
+	return false
+}
+
+func joinColumns(columns []*writeColumn) string {
+	var names = make([]string, 0, len(columns))
+	for _, column := range columns {
+		names = append(names, column.name)
+	}
+	return strings.Join(names, ", ")
+}
+
+func returning(option WriteOption) string {
+	if len(option.returning) == 0 {
+		return ""
+	}
+	return " RETURNING " + strings.Join(option.returning, ", ")
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
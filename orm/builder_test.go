@@ -0,0 +1,127 @@
+package orm_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github/pheethy/todo/helper"
+	"github/pheethy/todo/models"
+	"github/pheethy/todo/orm"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type builderItem struct {
+	TableName struct{}          `db:"items" pk:"Id"`
+	Id        *uuid.UUID        `db:"id" type:"uuid"`
+	Name      string            `db:"name" type:"string"`
+	Tags      []string          `db:"tags" type:"text[]"`
+	Meta      map[string]string `db:"meta" type:"jsonb"`
+	Note      string            `db:"note"`
+	Count     int64             `db:"count" type:"int64"`
+	Computed  string            `db:"-"`
+}
+
+func TestInsert(t *testing.T) {
+	id := uuid.FromStringOrNil("5fb3f1b6-3a5c-4e53-a4c5-2a0f5a3d0c11")
+	now := helper.NewTimestampFromTime(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC))
+	task := &models.Task{Id: &id, TaskName: "write", Status: "todo", CreatorName: "admin", CreatedAt: &now, UpdatedAt: &now}
+
+	t.Run("all_columns", func(t *testing.T) {
+		sql, args, err := orm.Insert(task, orm.NewWriteOption())
+		assert.NoError(t, err)
+		assert.Equal(t, "INSERT INTO todo (id, task_name, status, creator_name, created_at, deleted_at, updated_at) "+
+			"VALUES ($1::uuid, $2::text, $3::todo_status, $4::text, $5::timestamp, $6::timestamp, $7::timestamp)", sql)
+		assert.Equal(t, []interface{}{&id, "write", "todo", "admin", &now, (*helper.Timestamp)(nil), &now}, args)
+	})
+
+	t.Run("skip_zero_returning", func(t *testing.T) {
+		draft := &models.Task{TaskName: "draft", Status: "todo"}
+		sql, args, err := orm.Insert(draft, orm.NewWriteOption().SetSkipZero().SetReturning("id", "created_at"))
+		assert.NoError(t, err)
+		assert.Equal(t, "INSERT INTO todo (task_name, status) VALUES ($1::text, $2::todo_status) RETURNING id, created_at", sql)
+		assert.Equal(t, []interface{}{"draft", "todo"}, args)
+	})
+
+	t.Run("registry_values", func(t *testing.T) {
+		item := &builderItem{Id: &id, Name: "box", Tags: []string{"a", `b "c"`}, Meta: map[string]string{"k": "v"}, Count: 2}
+		sql, args, err := orm.Insert(item, orm.NewWriteOption())
+		assert.NoError(t, err)
+		assert.Equal(t, "INSERT INTO items (id, name, tags, meta, note, count) "+
+			"VALUES ($1::uuid, $2::text, $3::text[], $4::jsonb, $5, $6::bigint)", sql)
+		assert.Equal(t, []interface{}{&id, "box", `{"a","b \"c\""}`, `{"k":"v"}`, "", int64(2)}, args)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, _, err := orm.Insert(nil, orm.NewWriteOption())
+		assert.True(t, errors.Is(err, orm.ErrMustNotNil))
+
+		_, _, err = orm.Insert(&models.Task{}, orm.NewWriteOption().SetSkipZero())
+		assert.True(t, errors.Is(err, orm.ErrNoColumns))
+
+		_, _, err = orm.Insert(task, orm.NewWriteOption().SetColumns("unknown"))
+		assert.True(t, errors.Is(err, orm.ErrFieldNotFound))
+	})
+}
+
+func TestInsertMany(t *testing.T) {
+	a, b := uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())
+	items := []*builderItem{{Id: &a, Name: "a"}, {Id: &b, Name: "b", Count: 3}}
+
+	sql, args, err := orm.InsertMany(items, orm.NewWriteOption().SetColumns("id", "name", "count").SetReturning("id"))
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO items (id, name, count) "+
+		"VALUES ($1::uuid, $2::text, $3::bigint), ($4::uuid, $5::text, $6::bigint) RETURNING id", sql)
+	assert.Equal(t, []interface{}{&a, "a", int64(0), &b, "b", int64(3)}, args)
+
+	_, _, err = orm.InsertMany([]*builderItem{}, orm.NewWriteOption())
+	assert.True(t, errors.Is(err, orm.ErrEmptyData))
+
+	_, _, err = orm.InsertMany(items[0], orm.NewWriteOption())
+	assert.True(t, errors.Is(err, orm.ErrMustBeSlice))
+
+	_, _, err = orm.InsertMany([]interface{}{items[0], &models.Task{Id: &a}}, orm.NewWriteOption())
+	assert.True(t, errors.Is(err, orm.ErrMustBeSameTable))
+}
+
+func TestUpdate(t *testing.T) {
+	id := uuid.Must(uuid.NewV4())
+	now := helper.NewTimestampFromTime(time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC))
+	task := &models.Task{Id: &id, TaskName: "renamed", Status: "doing", UpdatedAt: &now}
+
+	sql, args, err := orm.Update(task, orm.NewWriteOption().
+		SetColumns("task_name", "updated_at").
+		SetConditions("deleted_at IS NULL").
+		SetReturning("updated_at"))
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE todo SET task_name = $1::text, updated_at = $2::timestamp "+
+		"WHERE id = $3::uuid AND deleted_at IS NULL RETURNING updated_at", sql)
+	assert.Equal(t, []interface{}{"renamed", &now, &id}, args)
+
+	sql, _, err = orm.Update(task, orm.NewWriteOption().SetSkipZero())
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE todo SET task_name = $1::text, status = $2::todo_status, updated_at = $3::timestamp WHERE id = $4::uuid", sql)
+
+	order := &models.Order{Id: "O000001", Status: models.OrderStatus("shipping")}
+	sql, args, err = orm.Update(order, orm.NewWriteOption().SetColumns("status"))
+	assert.NoError(t, err)
+	assert.Equal(t, "UPDATE orders SET status = $1::order_status WHERE id = $2::text", sql)
+	assert.Equal(t, []interface{}{"shipping", "O000001"}, args)
+}
+
+func TestUpsert(t *testing.T) {
+	id := uuid.Must(uuid.NewV4())
+	item := &builderItem{Id: &id, Name: "box", Count: 1}
+
+	sql, args, err := orm.Upsert(item, orm.NewWriteOption().SetColumns("id", "name", "count"))
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO items (id, name, count) VALUES ($1::uuid, $2::text, $3::bigint) "+
+		"ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name, count = EXCLUDED.count", sql)
+	assert.Equal(t, []interface{}{&id, "box", int64(1)}, args)
+
+	sql, _, err = orm.Upsert(item, orm.NewWriteOption().SetColumns("name").SetConflict("name").SetReturning("id"))
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO items (name) VALUES ($1::text) ON CONFLICT (name) DO NOTHING RETURNING id", sql)
+}
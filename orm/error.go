@@ -5,8 +5,16 @@ import "errors"
 var (
 	ErrMustNotNil         = errors.New("data model must not be nil")
 	ErrMustBeStruct       = errors.New("data value must be type struct")
+	ErrMustBeSlice        = errors.New("data value must be type slice")
+	ErrEmptyData          = errors.New("data must not be empty")
+	ErrMustBeSameTable    = errors.New("data must be models of the same table")
+	ErrNoColumns          = errors.New("no column to write")
 	ErrFieldNotFound      = errors.New("field not found")
 	ErrTagValueNotFound   = errors.New("tag value not found")
 	ErrNotIdentifyFkField = errors.New("not identify fk field on tag")
 	ErrRegistryNotFound   = errors.New("registry not found")
+	ErrRegistryDuplicate  = errors.New("registry was duplicate")
+	ErrRegistryInvalid    = errors.New("registry must have a type name")
+	ErrArrayInvalid       = errors.New("array value is invalid")
+	ErrEnumValueInvalid   = errors.New("enum value is invalid")
 )
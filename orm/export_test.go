@@ -0,0 +1,8 @@
+package orm
+
+/* UnregisterType ลบ type ออกจาก GlobalRegistry ใช้เฉพาะในเทสเพื่อให้รันซ้ำได้ */
+func UnregisterType(typeName string) {
+	GlobalRegistry.mu.Lock()
+	defer GlobalRegistry.mu.Unlock()
+	delete(GlobalRegistry.types, typeName)
+}
@@ -176,7 +176,7 @@ func orm(ctx context.Context, model interface{}, rows *sqlx.Rows, options Mapper
 					}
 				}()
 
-				return bindReference(ctx, elem, refFields, allmodels)
+				return bindReference(ctx, elem, refFields, allmodels, options)
 			})
 		}
 		/* orm sub component */
@@ -260,61 +260,41 @@ func GetSelector(models interface{}) string {
 
 func fillValueList(ms *modelStruct, columns []*sql.ColumnType, values []interface{}, options MapperOption) (reflect.Value, error) {
 	slice := ms.modelSlice
-	model := ms.model
-	ptr := copy(reflect.ValueOf(model)).Interface()
-	if err := fillValue(ptr, columns, values); err != nil {
-		return slice, err
+	if ms.plan == nil {
+		plan, err := newRowPlan(ms, columns, options)
+		if err != nil {
+			return slice, err
+		}
+		ms.plan = plan
 	}
-	reflectValPtr := reflect.ValueOf(ptr)
-	exists, err := isDuplicateByPK(ms, slice, reflectValPtr, options)
-	if err != nil {
-		return slice, err
+
+	ptr := copy(reflect.ValueOf(ms.model)).Interface()
+	if len(values) > 0 {
+		if err := ms.plan.fill(ptr, values); err != nil {
+			return slice, err
+		}
 	}
-	if !exists {
+	reflectValPtr := reflect.ValueOf(ptr)
+	if !isDuplicateByPK(ms, slice, reflectValPtr) {
 		slice = reflect.Append(slice, reflectValPtr)
 	}
 	return slice, nil
 }
 
-func isDuplicateByPK(ms *modelStruct, slice reflect.Value, ptr reflect.Value, options MapperOption) (bool, error) {
-	pkM := ms.pkM
-	faith := structs.New(ptr.Interface())
-	var storePK = func(elemFaith *structs.Struct, fields []string) (bool, error) {
-		pkId, err := getIds(elemFaith, fields)
-		if err != nil {
-			return false, err
-		}
-		if pkId == "" || pkId == "0" || pkId == "false" {
-			return true, nil
-		}
-		if _, ok := pkM.Load(pkId); ok {
-			return true, nil
-		}
-		pkM.Store(pkId, slice.Len()-1)
-
-		return false, nil
+func isDuplicateByPK(ms *modelStruct, slice reflect.Value, ptr reflect.Value) bool {
+	pkId := ms.plan.id(ptr)
+	if pkId == "" || pkId == "0" || pkId == "false" {
+		return true
 	}
-
-	var allFields = []string{}
-	var elemFaith *structs.Struct
-	switch ms.IsMainModel() {
-	case true:
-		/* for main model */
-		allFields, _ = getFieldMetaData(faith, options)
-		elemFaith = structs.New(ptr.Interface())
-	default:
-		/* for reference model */
-		allFields, _ = getFieldMetaData(faith, options)
-		elemFaith = structs.New(ptr.Interface())
-		if len(ms.refFields) > 0 {
-			allFields = append(allFields, ms.refFields...)
-		}
+	if _, ok := ms.pkM.Load(pkId); ok {
+		return true
 	}
+	ms.pkM.Store(pkId, slice.Len()-1)
 
-	return storePK(elemFaith, allFields)
+	return false
 }
 
-func bindReference(ctx context.Context, mainElem reflect.Value, mainRefFieldNames []string, allModels []modelStruct) error {
+func bindReference(ctx context.Context, mainElem reflect.Value, mainRefFieldNames []string, allModels []modelStruct, options MapperOption) error {
 	faith := structs.New(mainElem.Interface())
 	if len(mainRefFieldNames) > 0 {
 		var group, _ = errgroup.WithContext(ctx)
@@ -340,7 +320,7 @@ func bindReference(ctx context.Context, mainElem reflect.Value, mainRefFieldName
 						if !refModel.IsZero() && refModel.modelSlice.Len() > 0 {
 							for i := 0; i < refModel.modelSlice.Len(); i++ {
 								refVal := copy(refModel.modelSlice.Index(i))
-								if isJoin(faith, refVal.Interface(), fk.fkField1, fk.fkField2) {
+								if isJoin(faith, refVal.Interface(), fk.fkField1, fk.fkField2, options) {
 									if pkFieldRefDataField.Type().Kind() == reflect.Ptr {
 										/* object */
 										pkFieldRefDataField = refVal
@@ -365,12 +345,12 @@ func bindReference(ctx context.Context, mainElem reflect.Value, mainRefFieldName
 	return nil
 }
 
-func isJoin(mainFaith *structs.Struct, refData interface{}, fkCol1Keys []string, fkCol2Keys []string) bool {
+func isJoin(mainFaith *structs.Struct, refData interface{}, fkCol1Keys []string, fkCol2Keys []string, options MapperOption) bool {
 	checkEqual := func(fkCol1, fkCol2 string) bool {
 		parentID := mainFaith.Field(fkCol1).Value()
 		parentType := mainFaith.Field(fkCol1).Tag(TAG_TYPE)
 		linkID := structs.New(refData).Field(fkCol2).Value()
-		return equal(parentType, parentID, linkID)
+		return equal(parentType, parentID, linkID, options)
 	}
 	var totalValid = len(fkCol1Keys)
 	var isValid int
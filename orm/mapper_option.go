@@ -5,6 +5,7 @@ import "github.com/fatih/structs"
 type MapperOption struct {
 	autobinding bool
 	pkFields    []MapperOptionPkField
+	registry    *TypeRegistry
 }
 
 type MapperOptionPkField struct {
@@ -37,3 +38,19 @@ func (m MapperOption) SetOverridePKField(fields ...MapperOptionPkField) MapperOp
 	m.pkFields = fields
 	return m
 }
+
+/* SetRegistry type ใน registry ใช้ก่อน GlobalRegistry จึงใช้แทน type ที่ชื่อซ้ำกันได้เฉพาะ mapper นี้ */
+func (m MapperOption) SetRegistry(registry *TypeRegistry) MapperOption {
+	m.registry = registry
+	return m
+}
+
+/* lookupType ค้นหาจาก registry ของ mapper ก่อนแล้วจึงค้นหาจาก GlobalRegistry */
+func (m MapperOption) lookupType(typeName string) (Registry, bool) {
+	if m.registry != nil {
+		if registry, ok := m.registry.Lookup(typeName); ok {
+			return registry, true
+		}
+	}
+	return GlobalRegistry.Lookup(typeName)
+}
@@ -1,7 +1,9 @@
 package orm_test
 
 import (
+	"database/sql/driver"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"testing"
 	"time"
@@ -13,6 +15,10 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/DATA-DOG/go-sqlmock.v2"
+
+	/* benchmark วัด orm ของ repo นี้ ไม่ใช่ของ psql */
+	"github/pheethy/todo/helper"
+	localOrm "github/pheethy/todo/orm"
 )
 
 type Order struct {
@@ -580,3 +586,97 @@ func TestMapper(t *testing.T) {
 		}
 	})
 }
+
+/* benchOrder เหมือน Order แต่ใช้ type ที่ registry ของ repo นี้ bind ได้ */
+type benchOrder struct {
+	TableName struct{}          `json:"-" db:"orders" pk:"ID"`
+	ID        *uuid.UUID        `json:"id" db:"id" type:"uuid"`
+	Type      string            `json:"type" db:"type" type:"string"`
+	Name      string            `json:"name" db:"name" type:"string"`
+	Ppu       float64           `json:"ppu" db:"ppu" type:"float64"`
+	Status    int               `json:"status" db:"status" type:"int32"`
+	Enable    bool              `json:"enable" db:"enable" type:"bool"`
+	CreatedAt *helper.Timestamp `json:"created_at" db:"created_at" type:"timestamp"`
+	UpdatedAt *helper.Timestamp `json:"updated_at" db:"updated_at" type:"timestamp"`
+
+	Toppings []*benchTopping `json:"toppings" db:"-" fk:"fk_field1:ID,fk_field2:OrderId"`
+}
+
+type benchTopping struct {
+	TableName struct{}   `json:"-" db:"toppings" pk:"ID"`
+	ID        int        `json:"id" db:"id" type:"int32"`
+	Type      string     `json:"type" db:"type" type:"string"`
+	OrderId   *uuid.UUID `json:"order_id" db:"order_id" type:"uuid"`
+}
+
+/* benchOrders สร้าง order n แถว แต่ละแถวมี topping ตาม toppings (0 คือไม่ join) */
+func benchOrders(n int, toppings int) ([]string, [][]driver.Value) {
+	var columns = []string{
+		"orders.id", "orders.type", "orders.name", "orders.ppu", "orders.status", "orders.enable", "orders.created_at", "orders.updated_at",
+	}
+	if toppings > 0 {
+		columns = append(columns, "toppings.id", "toppings.type", "toppings.order_id")
+	}
+
+	var now = time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	var values = make([][]driver.Value, 0, n*(toppings+1))
+	for i := 0; i < n; i++ {
+		id := uuid.Must(uuid.NewV4()).String()
+		order := []driver.Value{id, "donut", fmt.Sprintf("order %d", i), 0.55, 1, true, now, now}
+		if toppings == 0 {
+			values = append(values, order)
+			continue
+		}
+		for j := 0; j < toppings; j++ {
+			values = append(values, append(order[:len(order):len(order)], i*toppings+j, "glazed", id))
+		}
+	}
+	return columns, values
+}
+
+func benchmarkOrm(b *testing.B, n int, toppings int) {
+	db, dbmock, err := sqlmock.New()
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer db.Close()
+	sqlxDB := sqlx.NewDb(db, "sqlmock")
+	columns, values := benchOrders(n, toppings)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		rows := sqlmock.NewRows(columns)
+		for _, row := range values {
+			rows.AddRow(row...)
+		}
+		dbmock.ExpectQuery(`SELECT (.+) orders`).WillReturnRows(rows)
+		b.StartTimer()
+
+		result, err := sqlxDB.Queryx(`SELECT * FROM orders`)
+		if err != nil {
+			b.Fatal(err)
+		}
+		mapper, err := localOrm.Orm(new(benchOrder), result, localOrm.NewMapperOption())
+		result.Close()
+		if err != nil {
+			b.Fatal(err)
+		}
+		if got := mapper.GetRowCount(); got != len(values) {
+			b.Fatalf("row count %d, want %d", got, len(values))
+		}
+	}
+}
+
+func BenchmarkOrm(b *testing.B) {
+	b.Run("orders_1000", func(b *testing.B) {
+		benchmarkOrm(b, 1000, 0)
+	})
+	b.Run("orders_10000", func(b *testing.B) {
+		benchmarkOrm(b, 10000, 0)
+	})
+	b.Run("orders_500_join_toppings", func(b *testing.B) {
+		benchmarkOrm(b, 500, 2)
+	})
+}
@@ -0,0 +1,152 @@
+package orm
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/fatih/structs"
+)
+
+/* typeMetaCache เก็บ *typeMeta ต่อ reflect.Type ของ struct */
+var typeMetaCache sync.Map
+
+/* typeMeta ค่าจาก tag ของ model หนึ่ง type อ่านครั้งเดียวแล้วใช้ซ้ำทุกแถวและทุก Orm */
+type typeMeta struct {
+	name      string
+	tableName string
+	columns   map[string]string // column ใน tag db -> ชื่อ field
+	types     map[string]string // ชื่อ field -> tag type
+	index     map[string][]int  // ชื่อ field -> index สำหรับ reflect.Value.FieldByIndex
+	pkFields  []string
+	fkFields  []string
+}
+
+/* getTypeMeta model เป็น struct หรือ pointer ของ struct */
+func getTypeMeta(model interface{}) *typeMeta {
+	modelType := reflect.TypeOf(model)
+	for modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+	if meta, ok := typeMetaCache.Load(modelType); ok {
+		return meta.(*typeMeta)
+	}
+	meta, _ := typeMetaCache.LoadOrStore(modelType, newTypeMeta(modelType))
+	return meta.(*typeMeta)
+}
+
+func newTypeMeta(modelType reflect.Type) *typeMeta {
+	faith := structs.New(reflect.New(modelType).Interface())
+	meta := &typeMeta{
+		name:      faith.Name(),
+		tableName: getTableName(faith),
+		columns:   make(map[string]string),
+		types:     make(map[string]string),
+		index:     make(map[string][]int),
+		pkFields:  strings.Split(getTagValue(faith, TABLE_FIELD_NAME, TAG_PK), fieldSeperate),
+		fkFields:  make([]string, 0),
+	}
+
+	for _, field := range faith.Fields() {
+		structField, _ := modelType.FieldByName(field.Name())
+		meta.index[field.Name()] = structField.Index
+		meta.types[field.Name()] = field.Tag(TAG_TYPE)
+		if column := field.Tag(TAGNAME); column != "" && column != "-" {
+			meta.columns[column] = field.Name()
+		}
+		if !field.IsEmbedded() && field.Tag(TAG_FK) != "" {
+			meta.fkFields = append(meta.fkFields, field.Name())
+		}
+	}
+
+	return meta
+}
+
+/*
+rowPlan การจับคู่ column ของ result set กับ field และ registry ของ modelStruct หนึ่งตัว
+สร้างตอนแถวแรกของ Orm แต่ละครั้ง เพราะ column และ registry ของ mapper อาจต่างกันในแต่ละครั้ง
+*/
+type rowPlan struct {
+	binds []columnBind
+	ids   []idField
+}
+
+type columnBind struct {
+	value    int // index ใน values ของแถว
+	field    string
+	registry Registry
+}
+
+/* idField field ที่ใช้สร้าง id สำหรับตรวจแถวซ้ำ */
+type idField struct {
+	index    []int
+	registry Registry
+}
+
+func newRowPlan(ms *modelStruct, columns []*sql.ColumnType, options MapperOption) (*rowPlan, error) {
+	meta := getTypeMeta(ms.model)
+	plan := &rowPlan{binds: make([]columnBind, 0, len(columns))}
+
+	for index, col := range columns {
+		fieldName, ok := meta.columns[strings.ReplaceAll(col.Name(), meta.tableName+".", "")]
+		if !ok {
+			continue
+		}
+		tag := meta.types[fieldName]
+		if tag == "" || tag == "-" {
+			continue
+		}
+		registry, ok := options.lookupType(tag)
+		if !ok {
+			return nil, fmt.Errorf("error: %s %w", tag, ErrRegistryNotFound)
+		}
+		plan.binds = append(plan.binds, columnBind{value: index, field: fieldName, registry: registry})
+	}
+
+	idFields, _ := getFieldMetaData(ms.model, options)
+	if !ms.IsMainModel() {
+		idFields = append(idFields, ms.refFields...)
+	}
+	for _, field := range idFields {
+		index, ok := meta.index[field]
+		if !ok {
+			log.Println("field ", field, "not found")
+			return nil, ErrFieldNotFound
+		}
+		registry, ok := options.lookupType(strings.TrimSpace(meta.types[field]))
+		if !ok {
+			return nil, ErrRegistryNotFound
+		}
+		plan.ids = append(plan.ids, idField{index: index, registry: registry})
+	}
+
+	return plan, nil
+}
+
+/* fill ใส่ค่าของแถวลงใน ptr ตาม column ที่จับคู่ไว้ */
+func (p *rowPlan) fill(ptr interface{}, values []interface{}) error {
+	faith := structs.New(ptr)
+	for _, bind := range p.binds {
+		if err := bind.registry.Bind(faith.Field(bind.field), values[bind.value]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/* id ค่า pk ของแถวรวมกันด้วย + คืนค่าว่างเมื่อ field ใดไม่มีค่า */
+func (p *rowPlan) id(ptr reflect.Value) string {
+	var elem = ptr.Elem()
+	var ids = make([]string, 0, len(p.ids))
+	for _, field := range p.ids {
+		id := field.registry.RegisterPkId(elem.FieldByIndex(field.index).Interface())
+		if id == "" {
+			return ""
+		}
+		ids = append(ids, id)
+	}
+	return strings.Join(ids, "+")
+}
@@ -19,6 +19,7 @@ type modelStruct struct {
 	refFields        []string // binding modelRef -> main
 	isReferenceModel bool
 	subRefModel      []modelStruct
+	plan             *rowPlan // สร้างตอนแถวแรกโดย fillValueList
 }
 
 type modelStructs []modelStruct
@@ -35,8 +36,7 @@ func newMainModelStruct(model interface{}, option MapperOption) modelStruct {
 		isReferenceModel: false,
 	}
 	if ms.IsMainModel() {
-		faith := structs.New(model)
-		pkFields, fkFields := getFieldMetaData(faith, option)
+		pkFields, fkFields := getFieldMetaData(model, option)
 
 		ms.pkFields = pkFields
 		ms.refFields = fkFields
@@ -67,7 +67,7 @@ func newModelStruct(model interface{}, options MapperOption) ([]modelStruct, err
 		return nil, err
 	}
 	faithModel := structs.New(model)
-	_, fkFields := getFieldMetaData(faithModel, options)
+	_, fkFields := getFieldMetaData(model, options)
 	mainModelStruct := newMainModelStruct(model, options)
 
 	var ms = make([]modelStruct, 0)
@@ -1,12 +1,9 @@
 package orm
 
 import (
-	"database/sql"
-	"fmt"
 	"log"
 	"reflect"
 	"strings"
-	"sync"
 
 	"github.com/fatih/structs"
 )
@@ -91,117 +88,31 @@ func getFieldValues(faith *structs.Struct, fields []string) ([]interface{}, erro
 	return vals, nil
 }
 
-func getFieldMetaData(faith *structs.Struct, option MapperOption) (pkFields []string, fkFields []string) {
-	pkValField := getTagValue(faith, TABLE_FIELD_NAME, TAG_PK)
-	pkFields = strings.Split(pkValField, fieldSeperate)
-	fkFields = make([]string, 0)
+/* getFieldMetaData pk ตาม tag หรือตาม SetOverridePKField และ field ที่มี tag fk ของ model คืน slice ใหม่ทุกครั้ง */
+func getFieldMetaData(model interface{}, option MapperOption) (pkFields []string, fkFields []string) {
+	meta := getTypeMeta(model)
+	pkFields = meta.pkFields
 
 	if len(option.pkFields) > 0 {
 		for _, pkField := range option.pkFields {
-			if pkField.faith.Name() == faith.Name() {
+			if pkField.faith.Name() == meta.name {
 				pkFields = pkField.fieldName
 				break
 			}
 		}
 	}
 
-	for _, field := range faith.Fields() {
-		if field.IsEmbedded() {
-			continue
-		}
-		if field.Tag(TAG_FK) != "" {
-			fkFields = append(fkFields, field.Name())
-		}
-	}
-
-	return pkFields, fkFields
-}
-
-func getStructFields(faith *structs.Struct) *sync.Map {
-	var ptrColumnMap = new(sync.Map)
-	fields := faith.Fields()
-	for _, f := range fields {
-		tagCol := f.Tag(TAGNAME)
-		if tagCol != "" && tagCol != "-" {
-			ptrColumnMap.Store(tagCol, f)
-		}
-	}
-
-	return ptrColumnMap
-}
-
-func setFieldFromType(field *structs.Field, data interface{}) error {
-	var tag = field.Tag(TAG_TYPE)
-	if tag != "" && tag != "-" {
-		registry, ok := GlobalRegistry[tag]
-		if !ok {
-			return fmt.Errorf("error: %s %s", tag, ErrRegistryNotFound.Error())
-		}
-		if err := registry.Bind(field, data); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
-func fillValue(ptr interface{}, columns []*sql.ColumnType, values []interface{}) error {
-	faith := structs.New(ptr)
-	schTableName := getTableName(faith)
-
-	ptrColumnMap := getStructFields(faith)
-
-	if len(values) > 0 {
-		for index, col := range columns {
-			orderCol := strings.ReplaceAll(col.Name(), schTableName+".", "")
-			if field, ok := ptrColumnMap.Load(orderCol); ok {
-				if err := setFieldFromType(field.(*structs.Field), values[index]); err != nil {
-					return err
-				}
-			}
-		}
-	}
-
-	return nil
-}
-
-func transfromIdString(faith *structs.Struct, field string, tag string, val interface{}) (string, error) {
-	typeVal := getTagValue(faith, field, tag)
-	registry, ok := GlobalRegistry[typeVal]
-	if !ok {
-		return "", ErrRegistryNotFound
-	}
-
-	return registry.RegisterPkId(val), nil
-}
-
-func getIds(faith *structs.Struct, fields []string) (string, error) {
-	var ids = []string{}
-	for _, field := range fields {
-		val, err := getFieldValue(faith, field)
-		if err != nil {
-			return "", err
-		}
-
-		id, err := transfromIdString(faith, field, TAG_TYPE, val)
-		if err != nil {
-			return "", err
-		}
-		if id == "" {
-			return "", nil
-		}
-		ids = append(ids, id)
-	}
-	return strings.Join(ids, "+"), nil
+	return append([]string(nil), pkFields...), append([]string(nil), meta.fkFields...)
 }
 
 /*
 Equal Value if a same type
 */
-func equal(tagTypeVal string, x interface{}, y interface{}) bool {
+func equal(tagTypeVal string, x interface{}, y interface{}, option MapperOption) bool {
 	if !isNil(x) && !isNil(y) {
-		registry := GlobalRegistry[tagTypeVal]
-		return registry.Equal(x, y)
+		if registry, ok := option.lookupType(tagTypeVal); ok {
+			return registry.Equal(x, y)
+		}
 	}
 	return false
 }
@@ -1,8 +1,15 @@
 package orm
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"github/pheethy/todo/helper"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"git.innovasive.co.th/backend/models"
@@ -20,21 +27,96 @@ type Registry interface {
 	Equal(x interface{}, y interface{}) bool
 }
 
-var GlobalRegistry = map[string]Registry{
-	(uid{}).TypeName():                              uid{},
-	(str("")).TypeName():                            str(""),
-	(zerouid{}).TypeName():                          zerouid{},
-	(integer(0)).TypeName():                         (integer(0)),
-	(integer64(0)).TypeName():                       (integer64(0)),
-	(floater32(float32(0))).TypeName():              (floater32(0)),
-	(floater64(float64(0))).TypeName():              (floater64(0)),
-	(timestamp(helperModel.Timestamp{})).TypeName(): timestamp(helperModel.Timestamp{}),
-	(date(helperModel.Date{})).TypeName():           date(helperModel.Date{}),
-	(zeroString(zeroString{})).TypeName():           zeroString(zero.String{}),
-	(zeroInt(zero.Int{})).TypeName():                zeroInt(zero.Int{}),
-	(zeroFloat(zero.Float{})).TypeName():            zeroFloat(zero.Float{}),
-	(zeroBool(zero.Bool{})).TypeName():              zeroBool(zero.Bool{}),
-	(boolean(true)).TypeName():                      (boolean(true)),
+/*
+GlobalRegistry type ที่ใช้ได้กับทุก mapper มี type พื้นฐานลงทะเบียนไว้แล้ว
+เพิ่ม type ของแต่ละ service ด้วย RegisterType ก่อนเริ่มใช้ mapper
+*/
+var GlobalRegistry = mustNewRegistry(
+	uid{},
+	str(""),
+	zerouid{},
+	integer(0),
+	integer64(0),
+	floater32(0),
+	floater64(0),
+	timestamp(helperModel.Timestamp{}),
+	date(helperModel.Date{}),
+	zeroString(zero.String{}),
+	zeroInt(zero.Int{}),
+	zeroFloat(zero.Float{}),
+	zeroBool(zero.Bool{}),
+	boolean(true),
+	jsonb("json"),
+	jsonb("jsonb"),
+	pgArray{name: "text[]", parse: func(s string) (interface{}, error) { return s, nil }},
+	pgArray{name: "int[]", parse: func(s string) (interface{}, error) { return strconv.ParseInt(s, 10, 64) }},
+	pgArray{name: "uuid[]", parse: func(s string) (interface{}, error) { return uuid.FromString(s) }},
+)
+
+/* RegisterType เพิ่ม type ลงใน GlobalRegistry ชื่อที่ซ้ำกับ type ที่มีอยู่แล้วคืน ErrRegistryDuplicate */
+func RegisterType(registry Registry) error {
+	return GlobalRegistry.Register(registry)
+}
+
+/*
+TypeRegistry ชุดของ Registry ที่ค้นหาด้วย TypeName ซึ่งตรงกับ tag type:"..." ของ field
+ลงทะเบียนและค้นหาพร้อมกันจากหลาย goroutine ได้
+*/
+type TypeRegistry struct {
+	mu    sync.RWMutex
+	types map[string]Registry
+}
+
+/* NewRegistry ชุด type ว่างสำหรับใช้กับ MapperOption.SetRegistry */
+func NewRegistry(registries ...Registry) (*TypeRegistry, error) {
+	var r = &TypeRegistry{types: make(map[string]Registry)}
+	for _, registry := range registries {
+		if err := r.Register(registry); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func mustNewRegistry(registries ...Registry) *TypeRegistry {
+	r, err := NewRegistry(registries...)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func (r *TypeRegistry) Register(registry Registry) error {
+	if registry == nil || registry.TypeName() == "" || registry.TypeName() == "-" {
+		return ErrRegistryInvalid
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.types[registry.TypeName()]; ok {
+		return fmt.Errorf("error: %s %w", registry.TypeName(), ErrRegistryDuplicate)
+	}
+	r.types[registry.TypeName()] = registry
+	return nil
+}
+
+func (r *TypeRegistry) Lookup(typeName string) (Registry, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	registry, ok := r.types[typeName]
+	return registry, ok
+}
+
+/* TypeNames ชื่อ type ทั้งหมดเรียงตามตัวอักษร */
+func (r *TypeRegistry) TypeNames() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var names = make([]string, 0, len(r.types))
+	for name := range r.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
 /*
@@ -577,3 +659,443 @@ func (elem boolean) Bind(field *structs.Field, val interface{}) error {
 func (elem boolean) Equal(x interface{}, y interface{}) bool {
 	return cast.ToBool(x) == cast.ToBool(y)
 }
+
+/*
+----------------------------------------
+|
+|	json, jsonb
+|
+----------------------------------------
+*/
+
+/*
+jsonb column json และ jsonb ที่ driver คืนเป็น []byte หรือ string (select ด้วย ::text)
+unmarshal ลง field ได้ทุกชนิดเช่น struct pointer, map, slice ส่วน field string จะได้ข้อความ json ตรงๆ
+*/
+type jsonb string
+
+func (elem jsonb) TypeName() string {
+	return string(elem)
+}
+
+/* RegisterPkId key ของ json เดียวกันต้องได้ค่าเดิมเสมอไม่ว่าลำดับ key หรือช่องว่างจะต่างกัน */
+func (elem jsonb) RegisterPkId(val interface{}) string {
+	return canonicalJSON(val)
+}
+
+func (elem jsonb) Bind(field *structs.Field, val interface{}) error {
+	data, ok := jsonBytes(val)
+	if !ok {
+		return nil
+	}
+
+	fieldType := reflect.TypeOf(field.Value())
+	if fieldType == nil {
+		/* field interface{} */
+		var v interface{}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return fmt.Errorf("error: %s %w", field.Name(), err)
+		}
+		return field.Set(v)
+	}
+	if fieldType.Kind() == reflect.String {
+		return field.Set(reflect.ValueOf(string(data)).Convert(fieldType).Interface())
+	}
+
+	ptr := reflect.New(fieldType)
+	if err := json.Unmarshal(data, ptr.Interface()); err != nil {
+		return fmt.Errorf("error: %s %w", field.Name(), err)
+	}
+	return field.Set(ptr.Elem().Interface())
+}
+
+func (elem jsonb) PgType() string {
+	return string(elem)
+}
+
+/* Value ส่ง json เป็นข้อความ field ที่เป็น nil จะเป็น NULL */
+func (elem jsonb) Value(val interface{}) (interface{}, error) {
+	switch v := val.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if v == "" {
+			return nil, nil
+		}
+		return v, nil
+	case []byte:
+		if len(v) == 0 {
+			return nil, nil
+		}
+		return string(v), nil
+	}
+	if isNil(val) {
+		return nil, nil
+	}
+	bu, err := json.Marshal(val)
+	if err != nil {
+		return nil, err
+	}
+	return string(bu), nil
+}
+
+func (elem jsonb) Equal(x interface{}, y interface{}) bool {
+	keyX := canonicalJSON(x)
+	if keyX == "" {
+		return false
+	}
+	return keyX == canonicalJSON(y)
+}
+
+/* jsonBytes แปลงค่าจาก driver เป็น json คืน false เมื่อเป็น NULL */
+func jsonBytes(val interface{}) ([]byte, bool) {
+	var data []byte
+	switch v := val.(type) {
+	case nil:
+		return nil, false
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		/* driver ที่ decode json มาให้แล้ว เช่น map[string]interface{} */
+		bu, err := json.Marshal(v)
+		if err != nil {
+			return nil, false
+		}
+		data = bu
+	}
+	if len(bytes.TrimSpace(data)) == 0 || string(bytes.TrimSpace(data)) == "null" {
+		return nil, false
+	}
+	return data, true
+}
+
+/* canonicalJSON json ที่ไม่มีช่องว่างและเรียง key ของ object คืนค่าว่างเมื่อเป็น NULL หรือ zero value */
+func canonicalJSON(val interface{}) string {
+	if isNil(val) || reflect.ValueOf(val).IsZero() {
+		return ""
+	}
+
+	var data []byte
+	switch v := val.(type) {
+	case []byte:
+		data = v
+	case json.RawMessage:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		bu, err := json.Marshal(v)
+		if err != nil {
+			return ""
+		}
+		data = bu
+	}
+
+	/* UseNumber เก็บตัวเลขเป็นข้อความเดิม ตัวเลขที่เกิน 2^53 จึงไม่ถูกปัดเป็น float64 ค่าเดียวกัน */
+	var tree interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&tree); err != nil || tree == nil {
+		return ""
+	}
+	bu, _ := json.Marshal(tree)
+	return string(bu)
+}
+
+/*
+----------------------------------------
+|
+|	array (text[], int[], uuid[])
+|
+----------------------------------------
+*/
+
+/*
+pgArray array 1 มิติของ postgres ที่ driver คืนเป็นข้อความ เช่น {a,"b c",NULL}
+field เป็น slice ของ type ที่แปลงจากค่าของ element ได้ เช่น []string, []int, []uuid.UUID
+element ที่เป็น NULL ใส่ได้เฉพาะ slice ของ pointer เช่น []*string
+*/
+type pgArray struct {
+	name  string
+	parse func(s string) (interface{}, error)
+}
+
+func (elem pgArray) TypeName() string {
+	return elem.name
+}
+
+func (elem pgArray) RegisterPkId(val interface{}) string {
+	if isNil(val) || reflect.ValueOf(val).Len() == 0 {
+		return ""
+	}
+	bu, _ := json.Marshal(val)
+	return string(bu)
+}
+
+func (elem pgArray) Bind(field *structs.Field, val interface{}) error {
+	if val == nil {
+		return nil
+	}
+	items, err := arrayItems(val)
+	if err != nil {
+		return fmt.Errorf("error: %s %w", field.Name(), err)
+	}
+
+	fieldType := reflect.TypeOf(field.Value())
+	if fieldType == nil || fieldType.Kind() != reflect.Slice {
+		return fmt.Errorf("error: %s must be a slice for %s", field.Name(), elem.name)
+	}
+	itemType := fieldType.Elem()
+	slice := reflect.MakeSlice(fieldType, 0, len(items))
+	for _, item := range items {
+		if item == nil {
+			if itemType.Kind() != reflect.Ptr {
+				return fmt.Errorf("error: %s NULL element needs a slice of pointer: %w", field.Name(), ErrArrayInvalid)
+			}
+			slice = reflect.Append(slice, reflect.Zero(itemType))
+			continue
+		}
+
+		parsed, err := elem.parse(*item)
+		if err != nil {
+			return fmt.Errorf("error: %s element %q: %w", field.Name(), *item, ErrArrayInvalid)
+		}
+		value, err := convertTo(reflect.ValueOf(parsed), itemType)
+		if err != nil {
+			return fmt.Errorf("error: %s %w", field.Name(), err)
+		}
+		slice = reflect.Append(slice, value)
+	}
+	return field.Set(slice.Interface())
+}
+
+func (elem pgArray) PgType() string {
+	return elem.name
+}
+
+/* Value ส่ง array เป็นข้อความรูปแบบเดียวกับที่ postgres คืนมา เช่น {a,"b c",NULL} slice ที่เป็น nil จะเป็น NULL */
+func (elem pgArray) Value(val interface{}) (interface{}, error) {
+	if isNil(val) {
+		return nil, nil
+	}
+	rv := reflect.ValueOf(val)
+	if rv.Kind() != reflect.Slice {
+		return nil, ErrArrayInvalid
+	}
+	if rv.IsNil() {
+		return nil, nil
+	}
+
+	var items = make([]string, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		if isNil(rv.Index(i).Interface()) {
+			items = append(items, "NULL")
+			continue
+		}
+		item := fmt.Sprint(reflect.Indirect(rv.Index(i)).Interface())
+		item = strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(item)
+		items = append(items, `"`+item+`"`)
+	}
+	return "{" + strings.Join(items, ",") + "}", nil
+}
+
+func (elem pgArray) Equal(x interface{}, y interface{}) bool {
+	keyX := elem.RegisterPkId(x)
+	if keyX == "" {
+		return false
+	}
+	return keyX == elem.RegisterPkId(y)
+}
+
+/* arrayItems แยก element จากข้อความ array ของ postgres หรือ slice ที่ driver decode มาให้แล้ว */
+func arrayItems(val interface{}) ([]*string, error) {
+	switch v := val.(type) {
+	case []byte:
+		return parsePgArray(string(v))
+	case string:
+		return parsePgArray(v)
+	}
+
+	rv := reflect.ValueOf(val)
+	if rv.Kind() != reflect.Slice {
+		return nil, ErrArrayInvalid
+	}
+	var items = make([]*string, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		if isNil(rv.Index(i).Interface()) {
+			items = append(items, nil)
+			continue
+		}
+		item := fmt.Sprint(reflect.Indirect(rv.Index(i)).Interface())
+		items = append(items, &item)
+	}
+	return items, nil
+}
+
+/* parsePgArray อ่านข้อความ array 1 มิติ element ที่เป็น NULL คืนเป็น nil */
+func parsePgArray(src string) ([]*string, error) {
+	src = strings.TrimSpace(src)
+	if len(src) < 2 || src[0] != '{' || src[len(src)-1] != '}' {
+		return nil, ErrArrayInvalid
+	}
+	body := src[1 : len(src)-1]
+	var items = make([]*string, 0)
+	if strings.TrimSpace(body) == "" {
+		return items, nil
+	}
+
+	for i := 0; ; {
+		var b strings.Builder
+		var quoted bool
+		if body[i] == '"' {
+			quoted = true
+			for i++; i < len(body) && body[i] != '"'; i++ {
+				if body[i] == '\\' && i+1 < len(body) {
+					i++
+				}
+				b.WriteByte(body[i])
+			}
+			if i >= len(body) {
+				return nil, ErrArrayInvalid
+			}
+			i++
+		} else {
+			for ; i < len(body) && body[i] != ','; i++ {
+				if body[i] == '{' || body[i] == '"' {
+					/* array หลายมิติหรือ quote ผิดตำแหน่ง */
+					return nil, ErrArrayInvalid
+				}
+				b.WriteByte(body[i])
+			}
+		}
+
+		item := b.String()
+		if !quoted {
+			item = strings.TrimSpace(item)
+		}
+		if !quoted && strings.EqualFold(item, "NULL") {
+			items = append(items, nil)
+		} else {
+			items = append(items, &item)
+		}
+
+		if i >= len(body) {
+			return items, nil
+		}
+		if body[i] != ',' || i+1 >= len(body) {
+			return nil, ErrArrayInvalid
+		}
+		i++
+	}
+}
+
+/* convertTo แปลงค่าเป็น type ของ element ใน field รวมถึง pointer ของ type นั้น */
+func convertTo(value reflect.Value, target reflect.Type) (reflect.Value, error) {
+	if target.Kind() == reflect.Ptr {
+		inner, err := convertTo(value, target.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		ptr := reflect.New(target.Elem())
+		ptr.Elem().Set(inner)
+		return ptr, nil
+	}
+	if !value.Type().ConvertibleTo(target) {
+		return reflect.Value{}, fmt.Errorf("%s cannot be set to %s", value.Type(), target)
+	}
+	return value.Convert(target), nil
+}
+
+/*
+----------------------------------------
+|
+|	enum
+|
+----------------------------------------
+*/
+
+/* Enum type string ของ Go ที่ประกาศค่าที่อนุญาตของ enum ใน postgres */
+type Enum interface {
+	EnumValues() []string
+}
+
+/*
+NewEnumType registry ของ enum ชื่อ typeName ตาม tag type:"..." ค่าที่ไม่อยู่ใน EnumValues คืน ErrEnumValueInvalid
+field เป็น string, type ที่มี underlying เป็น string หรือ pointer ของ type เหล่านั้น
+*/
+func NewEnumType(typeName string, enum Enum) Registry {
+	var values = enum.EnumValues()
+	var allowed = make(map[string]bool, len(values))
+	for _, value := range values {
+		allowed[value] = true
+	}
+	return enumType{name: typeName, values: values, allowed: allowed}
+}
+
+type enumType struct {
+	name    string
+	values  []string
+	allowed map[string]bool
+}
+
+func (elem enumType) TypeName() string {
+	return elem.name
+}
+
+func (elem enumType) RegisterPkId(val interface{}) string {
+	return enumString(val)
+}
+
+func (elem enumType) Bind(field *structs.Field, val interface{}) error {
+	if val == nil {
+		return nil
+	}
+	value := enumString(val)
+	if !elem.allowed[value] {
+		return fmt.Errorf("error: %s %q is not a %s value (%s): %w", field.Name(), value, elem.name, strings.Join(elem.values, ", "), ErrEnumValueInvalid)
+	}
+
+	fieldType := reflect.TypeOf(field.Value())
+	if fieldType == nil {
+		return field.Set(value)
+	}
+	converted, err := convertTo(reflect.ValueOf(value), fieldType)
+	if err != nil {
+		return fmt.Errorf("error: %s %w", field.Name(), err)
+	}
+	return field.Set(converted.Interface())
+}
+
+func (elem enumType) PgType() string {
+	return elem.name
+}
+
+/* Value แปลง type ของ enum เป็น string ค่าว่างจะเป็น NULL */
+func (elem enumType) Value(val interface{}) (interface{}, error) {
+	if value := enumString(val); value != "" {
+		return value, nil
+	}
+	return nil, nil
+}
+
+func (elem enumType) Equal(x interface{}, y interface{}) bool {
+	keyX := enumString(x)
+	if keyX == "" {
+		return false
+	}
+	return keyX == enumString(y)
+}
+
+/* enumString ค่า string ของ type ที่มี underlying เป็น string ซึ่ง cast.ToString แปลงไม่ได้ */
+func enumString(val interface{}) string {
+	if isNil(val) {
+		return ""
+	}
+	rv := reflect.Indirect(reflect.ValueOf(val))
+	if rv.Kind() != reflect.String {
+		return cast.ToString(val)
+	}
+	return rv.String()
+}
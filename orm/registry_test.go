@@ -0,0 +1,305 @@
+package orm_test
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github/pheethy/todo/models"
+	"github/pheethy/todo/orm"
+
+	"github.com/BlackMocca/sqlx"
+	"github.com/fatih/structs"
+	"github.com/gofrs/uuid"
+	"github.com/spf13/cast"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/DATA-DOG/go-sqlmock.v2"
+)
+
+/* money จำนวนเงินหน่วยสตางค์ ตัวอย่าง type ที่ service ลงทะเบียนเอง */
+type money struct {
+	name  string
+	scale int64
+}
+
+func (m money) TypeName() string {
+	return m.name
+}
+func (m money) RegisterPkId(val interface{}) string {
+	return cast.ToString(val)
+}
+func (m money) Bind(field *structs.Field, val interface{}) error {
+	if val == nil {
+		return nil
+	}
+	return field.Set(cast.ToInt64(val) * m.scale)
+}
+func (m money) Equal(x interface{}, y interface{}) bool {
+	return x == y
+}
+
+type wallet struct {
+	TableName struct{} `json:"-" db:"wallets" pk:"ID"`
+	ID        int      `json:"id" db:"id" type:"int32"`
+	Balance   int64    `json:"balance" db:"balance" type:"cents"`
+}
+
+func TestRegisterType(t *testing.T) {
+	/* ลงทะเบียนใน registry ของเทสเอง เพื่อไม่ให้ GlobalRegistry ค้าง type ไว้เมื่อรันเทสซ้ำ */
+	registry, err := orm.NewRegistry()
+	assert.NoError(t, err)
+	assert.NoError(t, registry.Register(money{name: "money_test", scale: 1}))
+	assert.ErrorIs(t, registry.Register(money{name: "money_test", scale: 1}), orm.ErrRegistryDuplicate)
+	assert.ErrorIs(t, registry.Register(nil), orm.ErrRegistryInvalid)
+	assert.ErrorIs(t, registry.Register(money{}), orm.ErrRegistryInvalid)
+
+	/* กรณีที่ error ไม่เปลี่ยน GlobalRegistry */
+	assert.ErrorIs(t, orm.RegisterType(money{name: "uuid"}), orm.ErrRegistryDuplicate)
+	assert.ErrorIs(t, orm.RegisterType(nil), orm.ErrRegistryInvalid)
+	assert.ErrorIs(t, orm.RegisterType(money{}), orm.ErrRegistryInvalid)
+
+	_, err = orm.NewRegistry(money{name: "cent"}, money{name: "cent"})
+	assert.ErrorIs(t, err, orm.ErrRegistryDuplicate)
+}
+
+func TestRegistryConcurrent(t *testing.T) {
+	registry, err := orm.NewRegistry()
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, registry.Register(money{name: fmt.Sprintf("money_%d", i)}))
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			registry.Lookup(fmt.Sprintf("money_%d", i))
+		}(i)
+	}
+	wg.Wait()
+	assert.Len(t, registry.TypeNames(), 50)
+}
+
+func TestMapperRegistryOverride(t *testing.T) {
+	var query = func(t *testing.T, option orm.MapperOption) ([]*wallet, error) {
+		db, dbmock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		defer db.Close()
+
+		dbmock.ExpectQuery("SELECT (.+) wallets").WillReturnRows(
+			sqlmock.NewRows([]string{"wallets.id", "wallets.balance"}).AddRow(1, 250),
+		)
+		rows, err := sqlx.NewDb(db, "sqlmock").Queryx("SELECT * FROM wallets")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rows.Close()
+
+		mapper, err := orm.Orm(new(wallet), rows, option)
+		if err != nil {
+			return nil, err
+		}
+		return mapper.GetData().([]*wallet), nil
+	}
+
+	_, err := query(t, orm.NewMapperOption())
+	assert.True(t, errors.Is(err, orm.ErrRegistryNotFound))
+
+	assert.NoError(t, orm.RegisterType(money{name: "cents", scale: 1}))
+	t.Cleanup(func() { orm.UnregisterType("cents") })
+	wallets, err := query(t, orm.NewMapperOption())
+	assert.NoError(t, err)
+	if assert.Len(t, wallets, 1) {
+		assert.Equal(t, int64(250), wallets[0].Balance)
+	}
+
+	/* registry ของ mapper ใช้แทน type ชื่อเดียวกันใน GlobalRegistry */
+	registry, err := orm.NewRegistry(money{name: "cents", scale: 100})
+	assert.NoError(t, err)
+	wallets, err = query(t, orm.NewMapperOption().SetRegistry(registry))
+	assert.NoError(t, err)
+	if assert.Len(t, wallets, 1) {
+		assert.Equal(t, int64(25000), wallets[0].Balance)
+	}
+}
+
+type slip struct {
+	Filename string `json:"filename"`
+	Amount   int    `json:"amount"`
+}
+
+type receipt struct {
+	TableName struct{}               `json:"-" db:"receipts" pk:"Key"`
+	Key       map[string]interface{} `json:"key" db:"key" type:"jsonb"`
+	Slip      *slip                  `json:"slip" db:"slip" type:"jsonb"`
+	Tags      []string               `json:"tags" db:"tags" type:"json"`
+	Raw       string                 `json:"raw" db:"raw" type:"jsonb"`
+}
+
+func TestMapperJsonb(t *testing.T) {
+	db, dbmock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	dbmock.ExpectQuery("SELECT (.+) receipts").WillReturnRows(
+		sqlmock.NewRows([]string{"receipts.key", "receipts.slip", "receipts.tags", "receipts.raw"}).
+			AddRow([]byte(`{"a": 1, "b": 2}`), []byte(`{"filename": "slip.png", "amount": 100}`), `["paid", "vip"]`, []byte(`{"x": true}`)).
+			AddRow(`{"b":2,"a":1}`, nil, nil, nil).
+			AddRow(`{"a":2}`, []byte(`null`), []byte(`[]`), nil),
+	)
+	rows, err := sqlx.NewDb(db, "sqlmock").Queryx("SELECT * FROM receipts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	mapper, err := orm.Orm(new(receipt), rows, orm.NewMapperOption())
+	assert.NoError(t, err)
+	receipts := mapper.GetData().([]*receipt)
+
+	/* แถวที่ 2 มี key เดียวกับแถวแรกแม้ลำดับ key และช่องว่างต่างกัน */
+	if assert.Len(t, receipts, 2) {
+		assert.Equal(t, map[string]interface{}{"a": float64(1), "b": float64(2)}, receipts[0].Key)
+		assert.Equal(t, &slip{Filename: "slip.png", Amount: 100}, receipts[0].Slip)
+		assert.Equal(t, []string{"paid", "vip"}, receipts[0].Tags)
+		assert.Equal(t, `{"x": true}`, receipts[0].Raw)
+
+		assert.Nil(t, receipts[1].Slip)
+		assert.Equal(t, []string{}, receipts[1].Tags)
+	}
+}
+
+type ledgerKey struct {
+	ID int64 `json:"id"`
+}
+
+type ledger struct {
+	TableName struct{}   `json:"-" db:"ledgers" pk:"Key"`
+	Key       *ledgerKey `json:"key" db:"key" type:"jsonb"`
+}
+
+func TestMapperJsonbBigNumberKey(t *testing.T) {
+	db, dbmock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	/* สอง id ต่างกันแต่เป็น float64 ค่าเดียวกัน ต้องไม่ถูกนับเป็นแถวซ้ำ */
+	dbmock.ExpectQuery("SELECT (.+) ledgers").WillReturnRows(
+		sqlmock.NewRows([]string{"ledgers.key"}).
+			AddRow([]byte(`{"id": 9007199254740993}`)).
+			AddRow([]byte(`{"id": 9007199254740992}`)),
+	)
+	rows, err := sqlx.NewDb(db, "sqlmock").Queryx("SELECT * FROM ledgers")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	mapper, err := orm.Orm(new(ledger), rows, orm.NewMapperOption())
+	assert.NoError(t, err)
+	ledgers := mapper.GetData().([]*ledger)
+	if assert.Len(t, ledgers, 2) {
+		assert.Equal(t, int64(9007199254740993), ledgers[0].Key.ID)
+		assert.Equal(t, int64(9007199254740992), ledgers[1].Key.ID)
+	}
+}
+
+type itemStatus string
+
+func (itemStatus) EnumValues() []string {
+	return []string{"draft", "done"}
+}
+
+type item struct {
+	TableName struct{}    `json:"-" db:"items" pk:"ID"`
+	ID        int         `json:"id" db:"id" type:"int32"`
+	Tags      []string    `json:"tags" db:"tags" type:"text[]"`
+	Notes     []*string   `json:"notes" db:"notes" type:"text[]"`
+	Scores    []int       `json:"scores" db:"scores" type:"int[]"`
+	OwnerIds  []uuid.UUID `json:"owner_ids" db:"owner_ids" type:"uuid[]"`
+	Status    itemStatus  `json:"status" db:"status" type:"item_status"`
+	Previous  *itemStatus `json:"previous" db:"previous" type:"item_status"`
+}
+
+func TestMapperArrayAndEnum(t *testing.T) {
+	registry, err := orm.NewRegistry(orm.NewEnumType("item_status", itemStatus("")))
+	assert.NoError(t, err)
+
+	var query = func(t *testing.T, rows *sqlmock.Rows) ([]*item, error) {
+		db, dbmock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		defer db.Close()
+
+		dbmock.ExpectQuery("SELECT (.+) items").WillReturnRows(rows)
+		sqlxRows, err := sqlx.NewDb(db, "sqlmock").Queryx("SELECT * FROM items")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer sqlxRows.Close()
+
+		mapper, err := orm.Orm(new(item), sqlxRows, orm.NewMapperOption().SetRegistry(registry))
+		if err != nil {
+			return nil, err
+		}
+		return mapper.GetData().([]*item), nil
+	}
+	var columns = []string{"items.id", "items.tags", "items.notes", "items.scores", "items.owner_ids", "items.status", "items.previous"}
+	var ownerId = uuid.Must(uuid.FromString("9b2f1c1e-3d5a-4c7e-8f00-1a2b3c4d5e6f"))
+
+	items, err := query(t, sqlmock.NewRows(columns).
+		AddRow(1, `{go,"hello, world","say \"hi\""}`, []byte(`{note,NULL}`), `{3,-1}`, `{9b2f1c1e-3d5a-4c7e-8f00-1a2b3c4d5e6f}`, "done", "draft").
+		AddRow(2, `{}`, nil, nil, nil, []byte("draft"), nil),
+	)
+	assert.NoError(t, err)
+	if assert.Len(t, items, 2) {
+		previous := itemStatus("draft")
+		assert.Equal(t, []string{"go", "hello, world", `say "hi"`}, items[0].Tags)
+		assert.Len(t, items[0].Notes, 2)
+		assert.Nil(t, items[0].Notes[1])
+		assert.Equal(t, []int{3, -1}, items[0].Scores)
+		assert.Equal(t, []uuid.UUID{ownerId}, items[0].OwnerIds)
+		assert.Equal(t, itemStatus("done"), items[0].Status)
+		assert.Equal(t, &previous, items[0].Previous)
+
+		assert.Equal(t, []string{}, items[1].Tags)
+		assert.Nil(t, items[1].Scores)
+		assert.Nil(t, items[1].Previous)
+	}
+
+	_, err = query(t, sqlmock.NewRows(columns).AddRow(3, nil, nil, nil, nil, "archived", nil))
+	assert.ErrorIs(t, err, orm.ErrEnumValueInvalid)
+	assert.Contains(t, err.Error(), `"archived" is not a item_status value (draft, done)`)
+
+	_, err = query(t, sqlmock.NewRows(columns).AddRow(4, `{a,NULL}`, nil, nil, nil, "done", nil))
+	assert.ErrorIs(t, err, orm.ErrArrayInvalid)
+
+	_, err = query(t, sqlmock.NewRows(columns).AddRow(5, nil, nil, `{1,x}`, nil, "done", nil))
+	assert.ErrorIs(t, err, orm.ErrArrayInvalid)
+}
+
+func TestEnumBindTyped(t *testing.T) {
+	registry, ok := orm.GlobalRegistry.Lookup("todo_status")
+	if !assert.True(t, ok) {
+		return
+	}
+
+	/* ค่าที่เป็น type ของ enum เอง ไม่ใช่ string หรือ []byte จาก driver */
+	task := new(models.Task)
+	assert.NoError(t, registry.Bind(structs.New(task).Field("Status"), models.TaskStatus("draft")))
+	assert.Equal(t, models.TaskStatus("draft"), task.Status)
+
+	err := registry.Bind(structs.New(task).Field("Status"), models.TaskStatus("archived"))
+	assert.ErrorIs(t, err, orm.ErrEnumValueInvalid)
+	assert.Contains(t, err.Error(), `"archived"`)
+}
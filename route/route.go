@@ -1,20 +1,80 @@
 package route
 
 import (
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/middleware"
+	"github/pheethy/todo/service/apikeys"
+	"github/pheethy/todo/service/orders"
+	"github/pheethy/todo/service/products"
 	"github/pheethy/todo/service/todo"
+	"github/pheethy/todo/service/users"
 
 	"github.com/gin-gonic/gin"
 )
 
+var (
+	allRoles  = []int{constants.ROLE_CUSTOMER, constants.ROLE_ADMIN}
+	adminOnly = []int{constants.ROLE_ADMIN}
+)
+
 type Route struct {
-	e *gin.Engine
+	e   *gin.Engine
+	mid middleware.Middleware
+}
+
+func NewRoute(e *gin.Engine, mid middleware.Middleware) *Route {
+	return &Route{e: e, mid: mid}
+}
+
+/* secure ทุก route ที่ต้อง login ต้องระบุ role ที่อนุญาต */
+func (r Route) secure(roles []int, handler gin.HandlerFunc) []gin.HandlerFunc {
+	return []gin.HandlerFunc{r.mid.JwtAuth(), r.mid.Authorize(roles...), handler}
 }
 
-func NewRoute(e *gin.Engine) *Route {
-	return &Route{e: e}
+/* secureWithApiKey เหมือน secure แต่ machine client ที่ส่ง X-API-Key ซึ่งมี scope ที่กำหนดเรียกได้ด้วย */
+func (r Route) secureWithApiKey(roles []int, scope string, handler gin.HandlerFunc) []gin.HandlerFunc {
+	roles = append([]int{constants.ROLE_MACHINE}, roles...)
+	return []gin.HandlerFunc{r.mid.JwtOrApiKeyAuth(scope), r.mid.Authorize(roles...), handler}
 }
 
-func (r Route) RegisterRoute(todoHandle todo.TodoHandler) {
-	r.e.POST("/task", todoHandle.CreateTask)
-	r.e.GET("/tasks", todoHandle.FetchListTodo)
+func (r Route) RegisterRoute(todoHandle todo.TodoHandler, usersHandle users.UsersHandler, apiKeysHandle apikeys.ApiKeysHandler, productsHandle products.ProductsHandler, ordersHandle orders.OrdersHandler) {
+	r.e.POST("/users/signup", usersHandle.SignUp)
+	r.e.POST("/users/signin", usersHandle.SignIn)
+	r.e.POST("/users/refresh", usersHandle.RefreshToken)
+	r.e.POST("/users/signout", r.secure(allRoles, usersHandle.SignOut)...)
+
+	r.e.POST("/api-keys", r.secure(adminOnly, apiKeysHandle.CreateApiKey)...)
+	r.e.GET("/api-keys", r.secure(adminOnly, apiKeysHandle.FetchListApiKey)...)
+	r.e.DELETE("/api-keys/:id", r.secure(adminOnly, apiKeysHandle.RevokeApiKey)...)
+
+	r.e.POST("/task", r.secureWithApiKey(allRoles, constants.API_KEY_SCOPE_TASKS_WRITE, todoHandle.CreateTask)...)
+	r.e.POST("/tasks/import", r.secure(adminOnly, todoHandle.ImportTasks)...)
+	r.e.GET("/tasks", r.secureWithApiKey(allRoles, constants.API_KEY_SCOPE_TASKS_READ, todoHandle.FetchListTodo)...)
+	r.e.GET("/tasks/trash", r.secure(adminOnly, todoHandle.FetchListTrash)...)
+	r.e.DELETE("/tasks/trash", r.secure(adminOnly, todoHandle.PurgeTrash)...)
+	r.e.GET("/tasks/search", r.secureWithApiKey(allRoles, constants.API_KEY_SCOPE_TASKS_READ, todoHandle.SearchTask)...)
+	r.e.GET("/task/:id", r.secureWithApiKey(allRoles, constants.API_KEY_SCOPE_TASKS_READ, todoHandle.FetchTaskById)...)
+	r.e.PUT("/task/:id", r.secure(allRoles, todoHandle.UpdateTask)...)
+	r.e.PATCH("/task/:id", r.secure(allRoles, todoHandle.UpdateTask)...)
+	r.e.DELETE("/task/:id", r.secure(allRoles, todoHandle.DeleteTask)...)
+	r.e.POST("/task/:id/transitions", r.secure(allRoles, todoHandle.TransitionTask)...)
+	r.e.POST("/task/:id/restore", r.secure(adminOnly, todoHandle.RestoreTask)...)
+	r.e.POST("/task/:id/attachments", r.secure(allRoles, todoHandle.UploadAttachment)...)
+	r.e.GET("/task/:id/attachments", r.secure(allRoles, todoHandle.FetchListAttachment)...)
+	r.e.GET("/task/:id/attachments/:attachment_id", r.secure(allRoles, todoHandle.DownloadAttachment)...)
+	r.e.DELETE("/task/:id/attachments/:attachment_id", r.secure(allRoles, todoHandle.DeleteAttachment)...)
+
+	r.e.GET("/products", productsHandle.FetchListProduct)
+	r.e.GET("/products/:id", productsHandle.FetchProductById)
+	r.e.POST("/products", r.secure(adminOnly, productsHandle.CreateProduct)...)
+	r.e.PUT("/products/:id", r.secure(adminOnly, productsHandle.UpdateProduct)...)
+	r.e.PATCH("/products/:id", r.secure(adminOnly, productsHandle.UpdateProduct)...)
+	r.e.DELETE("/products/:id", r.secure(adminOnly, productsHandle.DeleteProduct)...)
+	r.e.POST("/products/:id/images", r.secure(adminOnly, productsHandle.UploadImages)...)
+	r.e.DELETE("/products/:id/images/:image_id", r.secure(adminOnly, productsHandle.DeleteImage)...)
+
+	r.e.POST("/orders", r.secure(allRoles, ordersHandle.PlaceOrder)...)
+	r.e.GET("/orders", r.secure(allRoles, ordersHandle.FetchListOrder)...)
+	r.e.GET("/orders/:id", r.secure(allRoles, ordersHandle.FetchOrderById)...)
+	r.e.PATCH("/orders/:id/status", r.secure(allRoles, ordersHandle.UpdateOrderStatus)...)
 }
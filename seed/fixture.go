@@ -0,0 +1,129 @@
+package seed
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/models"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+/* fixtureFiles fixture ของแต่ละ environment อยู่ใน fixtures/<env>/ */
+//go:embed fixtures
+var fixtureFiles embed.FS
+
+/*
+Fixtures ข้อมูลที่จะ seed แต่ละไฟล์มีได้หลาย section และหลายไฟล์ใน environment เดียวกันจะถูกรวมกัน
+natural key ที่ใช้ upsert: users.username, products.title, orders (username, contact, address), tasks.task_name
+*/
+type Fixtures struct {
+	Users    []*UserFixture    `json:"users" yaml:"users"`
+	Products []*ProductFixture `json:"products" yaml:"products"`
+	Orders   []*OrderFixture   `json:"orders" yaml:"orders"`
+	Tasks    []*TaskFixture    `json:"tasks" yaml:"tasks"`
+}
+
+/* UserFixture ระบุ password หรือ password_hash (bcrypt) อย่างใดอย่างหนึ่ง role เป็น customer หรือ admin */
+type UserFixture struct {
+	Username     string `json:"username" yaml:"username"`
+	Email        string `json:"email" yaml:"email"`
+	Password     string `json:"password" yaml:"password"`
+	PasswordHash string `json:"password_hash" yaml:"password_hash"`
+	Role         string `json:"role" yaml:"role"`
+}
+
+/* ProductFixture category_ids อ้างถึง categories ที่สร้างไว้ใน migration */
+type ProductFixture struct {
+	Title       string          `json:"title" yaml:"title"`
+	Description string          `json:"description" yaml:"description"`
+	Price       float64         `json:"price" yaml:"price"`
+	CategoryIds []int           `json:"category_ids" yaml:"category_ids"`
+	Images      []*ImageFixture `json:"images" yaml:"images"`
+}
+
+type ImageFixture struct {
+	Filename string `json:"filename" yaml:"filename"`
+	Url      string `json:"url" yaml:"url"`
+}
+
+/* OrderFixture อ้างถึงผู้สั่งด้วย username และ product ด้วย title */
+type OrderFixture struct {
+	Username string                 `json:"username" yaml:"username"`
+	Contact  string                 `json:"contact" yaml:"contact"`
+	Address  string                 `json:"address" yaml:"address"`
+	Status   models.OrderStatus     `json:"status" yaml:"status"`
+	Products []*OrderProductFixture `json:"products" yaml:"products"`
+}
+
+type OrderProductFixture struct {
+	Title string `json:"title" yaml:"title"`
+	Qty   int    `json:"qty" yaml:"qty"`
+}
+
+type TaskFixture struct {
+	TaskName    string            `json:"task_name" yaml:"task_name"`
+	Status      models.TaskStatus `json:"status" yaml:"status"`
+	CreatorName string            `json:"creator_name" yaml:"creator_name"`
+}
+
+/* EmbeddedFixtures fixture ที่ embed ไว้ใน binary ใช้กับ LoadFixtures โดยส่งชื่อ environment เป็น dir */
+func EmbeddedFixtures() fs.FS {
+	sub, _ := fs.Sub(fixtureFiles, "fixtures")
+	return sub
+}
+
+/* LoadFixtures อ่านไฟล์ .json, .yaml และ .yml ใน dir เรียงตามชื่อไฟล์เพื่อให้ผลลัพธ์เหมือนเดิมทุกครั้ง */
+func LoadFixtures(fsys fs.FS, dir string) (*Fixtures, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, errors.New(constants.ERROR_SEED_FIXTURES_NOT_FOUND)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+
+	var fixtures = new(Fixtures)
+	var loaded int
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		var unmarshal func([]byte, interface{}) error
+		switch strings.ToLower(path.Ext(entry.Name())) {
+		case ".json":
+			unmarshal = json.Unmarshal
+		case ".yaml", ".yml":
+			unmarshal = yaml.Unmarshal
+		default:
+			continue
+		}
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		var file = new(Fixtures)
+		if err := unmarshal(content, file); err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+
+		fixtures.Users = append(fixtures.Users, file.Users...)
+		fixtures.Products = append(fixtures.Products, file.Products...)
+		fixtures.Orders = append(fixtures.Orders, file.Orders...)
+		fixtures.Tasks = append(fixtures.Tasks, file.Tasks...)
+		loaded++
+	}
+	if loaded == 0 {
+		return nil, errors.New(constants.ERROR_SEED_FIXTURES_NOT_FOUND)
+	}
+
+	return fixtures, nil
+}
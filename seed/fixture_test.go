@@ -0,0 +1,35 @@
+package seed
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadFixtures(t *testing.T) {
+	for _, env := range []string{"dev", "test"} {
+		fixtures, err := LoadFixtures(EmbeddedFixtures(), env)
+		assert.NoError(t, err, env)
+		assert.NotEmpty(t, fixtures.Users, env)
+		assert.NotEmpty(t, fixtures.Products, env)
+		assert.NotEmpty(t, fixtures.Tasks, env)
+	}
+
+	_, err := LoadFixtures(EmbeddedFixtures(), "staging")
+	assert.Error(t, err)
+}
+
+func TestGenerateTasks(t *testing.T) {
+	first := GenerateTasks(50, 7, "")
+	second := GenerateTasks(50, 7, "")
+	assert.Len(t, first, 50)
+	assert.Equal(t, first, second)
+
+	var names = make(map[string]bool)
+	for _, task := range first {
+		assert.False(t, names[task.TaskName], task.TaskName)
+		names[task.TaskName] = true
+		assert.NotEmpty(t, task.Status)
+	}
+	assert.NotEqual(t, first[0].TaskName, GenerateTasks(1, 8, "")[0].TaskName)
+}
@@ -0,0 +1,66 @@
+package seed
+
+import (
+	"fmt"
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/models"
+	"math/rand"
+)
+
+var (
+	taskVerbs    = []string{"Review", "Write", "Fix", "Refactor", "Deploy", "Test", "Document", "Design", "Migrate", "Benchmark", "Triage", "Update"}
+	taskObjects  = []string{"login flow", "payment webhook", "order history page", "product search", "api rate limiter", "invoice export", "push notifications", "user onboarding", "cache invalidation", "audit log", "image upload", "release notes"}
+	taskProjects = []string{"kawaii shop", "mobile app", "admin console", "billing service", "data pipeline", "public api"}
+	taskCreators = []string{"customer001", "admin001", "pheethy", "somchai", "malee", "loadtest"}
+
+	/* สัดส่วน status ใกล้เคียงข้อมูลจริง: draft มากที่สุด รองลงมาคือ in-progress */
+	taskStatusWeights = []struct {
+		status models.TaskStatus
+		weight int
+	}{
+		{constants.TASK_STATUS_DRAFT, 5},
+		{constants.TASK_STATUS_IN_PROGRESS, 3},
+		{constants.TASK_STATUS_DONE, 2},
+	}
+)
+
+/*
+GenerateTasks สร้าง task สุ่ม n รายการสำหรับ load test ค่า seed เดียวกันได้ task ชุดเดิมเสมอ
+ชื่อ task มีเลขลำดับต่อท้ายเพื่อไม่ให้ชน unique constraint และ seed ซ้ำได้แบบ idempotent
+creator ว่างจะสุ่มจากรายชื่อตัวอย่าง
+*/
+func GenerateTasks(n int, seed int64, creator string) []*TaskFixture {
+	var random = rand.New(rand.NewSource(seed))
+	var totalWeight int
+	for _, w := range taskStatusWeights {
+		totalWeight += w.weight
+	}
+
+	var tasks = make([]*TaskFixture, 0, n)
+	for i := 0; i < n; i++ {
+		task := &TaskFixture{
+			TaskName: fmt.Sprintf("%s %s for %s #%d-%d",
+				taskVerbs[random.Intn(len(taskVerbs))],
+				taskObjects[random.Intn(len(taskObjects))],
+				taskProjects[random.Intn(len(taskProjects))],
+				seed, i+1,
+			),
+			CreatorName: creator,
+		}
+		if task.CreatorName == "" {
+			task.CreatorName = taskCreators[random.Intn(len(taskCreators))]
+		}
+
+		pick := random.Intn(totalWeight)
+		for _, w := range taskStatusWeights {
+			if pick < w.weight {
+				task.Status = w.status
+				break
+			}
+			pick -= w.weight
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks
+}
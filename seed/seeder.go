@@ -0,0 +1,363 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/helper"
+	"github/pheethy/todo/models"
+	"github/pheethy/todo/service/orders"
+	"github/pheethy/todo/service/products"
+	"github/pheethy/todo/service/todo"
+	"github/pheethy/todo/service/users"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+/* createTaskBatchSize จำนวน task ใหม่ที่ insert ต่อ transaction ตอน seed task จำนวนมาก */
+const createTaskBatchSize = 500
+
+/* seedMovedBy ชื่อผู้เปลี่ยน status ที่บันทึกใน todo_transition เมื่อ seed เปลี่ยน status ของ task เดิม */
+const seedMovedBy = "seed"
+
+/* Result จำนวนข้อมูลที่สร้างใหม่ แก้ไข และไม่เปลี่ยนแปลง แยกตามชนิดข้อมูล */
+type Result struct {
+	Created   map[string]int `json:"created"`
+	Updated   map[string]int `json:"updated"`
+	Unchanged map[string]int `json:"unchanged"`
+}
+
+func newResult() *Result {
+	return &Result{Created: map[string]int{}, Updated: map[string]int{}, Unchanged: map[string]int{}}
+}
+
+func (r *Result) String() string {
+	var b strings.Builder
+	for _, kind := range []string{"users", "products", "orders", "tasks"} {
+		fmt.Fprintf(&b, "%-9s created %d, updated %d, unchanged %d\n", kind, r.Created[kind], r.Updated[kind], r.Unchanged[kind])
+	}
+	return b.String()
+}
+
+type Seeder interface {
+	Seed(ctx context.Context, fixtures *Fixtures) (*Result, error)
+}
+
+type seeder struct {
+	usersRepo    users.UsersRepository
+	productsRepo products.ProductsRepository
+	ordersRepo   orders.OrdersRepository
+	todoRepo     todo.TodoRepository
+}
+
+/* NewSeeder seed ผ่าน repository ของแต่ละ service เพื่อให้ข้อมูลผ่าน logic เดียวกับ api เช่น snapshot product ใน order */
+func NewSeeder(usersRepo users.UsersRepository, productsRepo products.ProductsRepository, ordersRepo orders.OrdersRepository, todoRepo todo.TodoRepository) Seeder {
+	return seeder{usersRepo: usersRepo, productsRepo: productsRepo, ordersRepo: ordersRepo, todoRepo: todoRepo}
+}
+
+/*
+Seed upsert ตาม natural key จึงรันซ้ำได้โดยไม่สร้างข้อมูลซ้ำ
+ลำดับคือ users, products, orders แล้วจึง tasks เพราะ order อ้างถึง user และ product
+user ที่มีอยู่แล้วจะไม่ถูกแก้ไข เพื่อไม่ให้รหัสผ่านที่ผู้ใช้เปลี่ยนไปแล้วถูกเขียนทับ
+*/
+func (s seeder) Seed(ctx context.Context, fixtures *Fixtures) (*Result, error) {
+	var result = newResult()
+
+	for _, fixture := range fixtures.Users {
+		if err := s.seedUser(ctx, fixture, result); err != nil {
+			return result, fmt.Errorf("user %q: %w", fixture.Username, err)
+		}
+	}
+	for _, fixture := range fixtures.Products {
+		if err := s.seedProduct(ctx, fixture, result); err != nil {
+			return result, fmt.Errorf("product %q: %w", fixture.Title, err)
+		}
+	}
+	for _, fixture := range fixtures.Orders {
+		if err := s.seedOrder(ctx, fixture, result); err != nil {
+			return result, fmt.Errorf("order of %q: %w", fixture.Username, err)
+		}
+	}
+	if err := s.seedTasks(ctx, fixtures.Tasks, result); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+func (s seeder) seedUser(ctx context.Context, fixture *UserFixture, result *Result) error {
+	_, err := s.usersRepo.FetchUserByUsername(ctx, fixture.Username)
+	if err == nil {
+		result.Unchanged["users"]++
+		return nil
+	}
+	if err.Error() != constants.ERROR_USER_NOT_FOUND {
+		return err
+	}
+
+	var roleId int
+	switch fixture.Role {
+	case "", "customer":
+		roleId = constants.ROLE_CUSTOMER
+	case "admin":
+		roleId = constants.ROLE_ADMIN
+	default:
+		return errors.New(constants.ERROR_SEED_ROLE_INVALID)
+	}
+
+	var hashed = fixture.PasswordHash
+	if hashed == "" {
+		b, err := bcrypt.GenerateFromPassword([]byte(fixture.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return err
+		}
+		hashed = string(b)
+	}
+
+	var now = helper.NewTimestampFromTime(time.Now())
+	var user = &models.User{
+		Username: fixture.Username,
+		Email:    strings.ToLower(fixture.Email),
+		Password: hashed,
+		RoleId:   roleId,
+	}
+	user.SetCreatedAt(now)
+	user.SetUpatedAt(now)
+	if err := s.usersRepo.CreateUser(ctx, user); err != nil {
+		return err
+	}
+
+	result.Created["users"]++
+	return nil
+}
+
+func (s seeder) seedProduct(ctx context.Context, fixture *ProductFixture, result *Result) error {
+	var now = helper.NewTimestampFromTime(time.Now())
+	var categories = make([]*models.Category, 0, len(fixture.CategoryIds))
+	for _, id := range fixture.CategoryIds {
+		categories = append(categories, &models.Category{Id: id})
+	}
+
+	product, err := s.productsRepo.FetchProductByTitle(ctx, fixture.Title)
+	switch {
+	case err == nil:
+		if product.Description == fixture.Description && product.Price == fixture.Price && sameInts(product.CategoryIds(), fixture.CategoryIds) {
+			result.Unchanged["products"]++
+			break
+		}
+		product.Description = fixture.Description
+		product.Price = fixture.Price
+		product.Categories = categories
+		product.SetUpatedAt(now)
+		if err := s.productsRepo.UpdateProduct(ctx, product); err != nil {
+			return err
+		}
+		result.Updated["products"]++
+	case err.Error() == constants.ERROR_PRODUCT_NOT_FOUND:
+		product = &models.Product{
+			Title:       fixture.Title,
+			Description: fixture.Description,
+			Price:       fixture.Price,
+			Categories:  categories,
+		}
+		product.SetCreatedAt(now)
+		product.SetUpatedAt(now)
+		if err := s.productsRepo.CreateProduct(ctx, product); err != nil {
+			return err
+		}
+		result.Created["products"]++
+	default:
+		return err
+	}
+
+	/* รูปที่มีชื่อไฟล์อยู่แล้วจะไม่ถูกเพิ่มซ้ำ */
+	var existing = make(map[string]bool, len(product.Images))
+	for _, image := range product.Images {
+		existing[image.Filename] = true
+	}
+	for _, fixtureImage := range fixture.Images {
+		if existing[fixtureImage.Filename] {
+			continue
+		}
+		image := &models.Image{Filename: fixtureImage.Filename, Url: fixtureImage.Url, ProductId: product.Id}
+		image.NewId()
+		image.SetCreatedAt(now)
+		image.SetUpatedAt(now)
+		if err := s.productsRepo.CreateImage(ctx, image); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s seeder) seedOrder(ctx context.Context, fixture *OrderFixture, result *Result) error {
+	var status = fixture.Status
+	if status == "" {
+		status = constants.ORDER_STATUS_WAITING
+	}
+	if !models.IsValidOrderStatus(status) {
+		return errors.New(constants.ERROR_ORDER_STATUS_INVALID)
+	}
+
+	user, err := s.usersRepo.FetchUserByUsername(ctx, fixture.Username)
+	if err != nil {
+		return err
+	}
+
+	var now = helper.NewTimestampFromTime(time.Now())
+	order, err := s.findOrder(ctx, user.Id, fixture.Contact, fixture.Address)
+	if err != nil {
+		return err
+	}
+	if order != nil {
+		if order.Status == status {
+			result.Unchanged["orders"]++
+			return nil
+		}
+		var fromStatus = order.Status
+		order.Status = status
+		order.SetUpatedAt(now)
+		if err := s.ordersRepo.UpdateOrderStatus(ctx, order, fromStatus); err != nil {
+			return err
+		}
+		result.Updated["orders"]++
+		return nil
+	}
+
+	order = &models.Order{
+		UserId:   user.Id,
+		Contact:  fixture.Contact,
+		Address:  fixture.Address,
+		Status:   status,
+		Products: make([]*models.ProductsOrder, 0, len(fixture.Products)),
+	}
+	order.SetCreatedAt(now)
+	order.SetUpatedAt(now)
+	for _, p := range fixture.Products {
+		product, err := s.productsRepo.FetchProductByTitle(ctx, p.Title)
+		if err != nil {
+			return fmt.Errorf("product %q: %w", p.Title, err)
+		}
+		item := &models.ProductsOrder{Qty: p.Qty, Product: product}
+		item.NewId()
+		order.Products = append(order.Products, item)
+	}
+	if err := s.ordersRepo.PlaceOrder(ctx, order); err != nil {
+		return err
+	}
+
+	result.Created["orders"]++
+	return nil
+}
+
+/* findOrder order ไม่มี natural key ใน schema จึงใช้ผู้สั่ง ผู้ติดต่อ และที่อยู่ร่วมกัน คืน nil เมื่อไม่พบ */
+func (s seeder) findOrder(ctx context.Context, userId string, contact string, address string) (*models.Order, error) {
+	var filter = &models.OrderFilter{UserId: userId}
+	for page := 1; ; page++ {
+		paginator := models.NewPaginator(page, models.MAX_PER_PAGE)
+		orders, err := s.ordersRepo.FetchListOrder(ctx, filter, paginator)
+		if err != nil {
+			return nil, err
+		}
+		for _, order := range orders {
+			if order.Contact == contact && order.Address == address {
+				return order, nil
+			}
+		}
+		if page >= paginator.TotalPages {
+			return nil, nil
+		}
+	}
+}
+
+/* seedTasks task ใหม่ถูก insert เป็นชุดละ createTaskBatchSize เพื่อให้ seed task จำนวนมากสำหรับ load test ได้เร็ว */
+func (s seeder) seedTasks(ctx context.Context, fixtures []*TaskFixture, result *Result) error {
+	var now = helper.NewTimestampFromTime(time.Now())
+	var pending = make([]*models.Task, 0, createTaskBatchSize)
+	var flush = func() error {
+		if len(pending) == 0 {
+			return nil
+		}
+		if err := s.todoRepo.CreateTasks(ctx, pending); err != nil {
+			return err
+		}
+		result.Created["tasks"] += len(pending)
+		pending = pending[:0]
+		return nil
+	}
+
+	var seen = make(map[string]bool, len(fixtures))
+	for _, fixture := range fixtures {
+		if seen[fixture.TaskName] {
+			result.Unchanged["tasks"]++
+			continue
+		}
+		seen[fixture.TaskName] = true
+
+		var status = fixture.Status
+		if status == "" {
+			status = constants.TASK_STATUS_DRAFT
+		}
+		if !models.IsValidTaskStatus(status) {
+			return fmt.Errorf("task %q: %w", fixture.TaskName, errors.New(constants.ERROR_TASK_STATUS_INVALID))
+		}
+
+		task, err := s.todoRepo.FetchTaskByName(ctx, fixture.TaskName)
+		switch {
+		case err == nil:
+			if task.Status == status {
+				result.Unchanged["tasks"]++
+				continue
+			}
+			/* fixture คือสถานะที่ต้องการ จึงไม่ตรวจ state machine แต่ยังบันทึก transition ไว้ */
+			transition := &models.TaskTransition{
+				TaskId:     task.Id,
+				FromStatus: task.Status,
+				ToStatus:   status,
+				MovedBy:    seedMovedBy,
+			}
+			transition.NewId()
+			transition.SetMovedAt(now)
+			task.Status = status
+			task.SetUpatedAt(now)
+			if err := s.todoRepo.TransitionTask(ctx, task, transition); err != nil {
+				return fmt.Errorf("task %q: %w", fixture.TaskName, err)
+			}
+			result.Updated["tasks"]++
+		case err.Error() == constants.ERROR_TASK_NOT_FOUND:
+			task = &models.Task{
+				TaskName:    fixture.TaskName,
+				Status:      status,
+				CreatorName: fixture.CreatorName,
+			}
+			task.NewId()
+			task.SetCreatedAt(now)
+			task.SetUpatedAt(now)
+			pending = append(pending, task)
+			if len(pending) == createTaskBatchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		default:
+			return fmt.Errorf("task %q: %w", fixture.TaskName, err)
+		}
+	}
+
+	return flush()
+}
+
+/* sameInts เทียบ id โดยไม่สนลำดับ */
+func sameInts(a []int, b []int) bool {
+	a = append([]int(nil), a...)
+	b = append([]int(nil), b...)
+	sort.Ints(a)
+	sort.Ints(b)
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,11 @@
+package apikeys
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type ApiKeysHandler interface {
+	CreateApiKey(c *gin.Context)
+	FetchListApiKey(c *gin.Context)
+	RevokeApiKey(c *gin.Context)
+}
@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"fmt"
+	"github/pheethy/todo/auth"
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/models"
+	"github/pheethy/todo/service/apikeys"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gofrs/uuid"
+)
+
+type apiKeysHandler struct {
+	apiKeysUs apikeys.ApiKeysUsecase
+}
+
+func NewApiKeysHandler(apiKeysUs apikeys.ApiKeysUsecase) apikeys.ApiKeysHandler {
+	return apiKeysHandler{apiKeysUs: apiKeysUs}
+}
+
+func (h apiKeysHandler) CreateApiKey(c *gin.Context) {
+	var ctx = c.Request.Context()
+	var req = new(models.ApiKeyCreate)
+
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("can't binding data: %v", err))
+		return
+	}
+
+	user := auth.UserFromContext(ctx)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, constants.ERROR_TOKEN_MISSING)
+		return
+	}
+
+	issued, err := h.apiKeysUs.CreateApiKey(ctx, req, user.Username)
+	if err != nil {
+		c.JSON(h.statusFromError(err), err.Error())
+		return
+	}
+
+	resp := map[string]interface{}{
+		"message": "Created. The key is shown only once, store it securely.",
+		"api_key": issued,
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (h apiKeysHandler) FetchListApiKey(c *gin.Context) {
+	var ctx = c.Request.Context()
+
+	apiKeys, err := h.apiKeysUs.FetchListApiKey(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	resp := map[string]interface{}{
+		"api_keys": apiKeys,
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h apiKeysHandler) RevokeApiKey(c *gin.Context) {
+	var ctx = c.Request.Context()
+
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, constants.ERROR_API_KEY_ID_INVALID)
+		return
+	}
+
+	if err := h.apiKeysUs.RevokeApiKey(ctx, &id); err != nil {
+		c.JSON(h.statusFromError(err), err.Error())
+		return
+	}
+
+	resp := map[string]interface{}{
+		"message": "Revoked.",
+		"id":      id,
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h apiKeysHandler) statusFromError(err error) int {
+	switch err.Error() {
+	case constants.ERROR_API_KEY_NAME_WAS_DUPLICATE_SERVICE:
+		return http.StatusConflict
+	case constants.ERROR_API_KEY_SCOPE_INVALID:
+		return http.StatusBadRequest
+	case constants.ERROR_API_KEY_NOT_FOUND:
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,17 @@
+package apikeys
+
+import (
+	"context"
+	"github/pheethy/todo/helper"
+	"github/pheethy/todo/models"
+
+	"github.com/gofrs/uuid"
+)
+
+type ApiKeysRepository interface {
+	CreateApiKey(ctx context.Context, apiKey *models.ApiKey) error
+	FetchListApiKey(ctx context.Context) ([]*models.ApiKey, error)
+	FetchApiKeyByHash(ctx context.Context, keyHash string) (*models.ApiKey, error)
+	RevokeApiKey(ctx context.Context, id *uuid.UUID, revokedAt *helper.Timestamp) error
+	UpdateLastUsedAt(ctx context.Context, id *uuid.UUID, lastUsedAt *helper.Timestamp) error
+}
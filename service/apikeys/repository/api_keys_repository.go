@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/helper"
+	"github/pheethy/todo/models"
+	"github/pheethy/todo/orm"
+	"github/pheethy/todo/service/apikeys"
+	"strings"
+
+	"github.com/BlackMocca/sqlx"
+	"github.com/gofrs/uuid"
+)
+
+type apiKeysRepository struct {
+	db *sqlx.DB
+}
+
+func NewApiKeysRepository(db *sqlx.DB) apikeys.ApiKeysRepository {
+	return apiKeysRepository{db: db}
+}
+
+func (r apiKeysRepository) CreateApiKey(ctx context.Context, apiKey *models.ApiKey) error {
+	sql := `
+		INSERT INTO api_keys (
+			id,
+			name,
+			prefix,
+			key_hash,
+			scopes,
+			created_by,
+			created_at,
+			updated_at
+		)
+		VALUES(
+			$1::uuid,
+			$2::text,
+			$3::text,
+			$4::text,
+			$5::text,
+			$6::text,
+			$7::timestamp,
+			$8::timestamp
+		)
+	`
+	if _, err := r.db.ExecContext(ctx, sql,
+		apiKey.Id,
+		apiKey.Name,
+		apiKey.Prefix,
+		apiKey.KeyHash,
+		apiKey.Scopes,
+		apiKey.CreatedBy,
+		apiKey.CreatedAt,
+		apiKey.UpdatedAt,
+	); err != nil {
+		if strings.Contains(err.Error(), constants.ERROR_API_KEY_NAME_WAS_DUPLICATE) {
+			return errors.New(constants.ERROR_API_KEY_NAME_WAS_DUPLICATE_SERVICE)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r apiKeysRepository) FetchListApiKey(ctx context.Context) ([]*models.ApiKey, error) {
+	sql := `
+	SELECT
+		id,
+		name,
+		prefix,
+		key_hash,
+		scopes,
+		created_by,
+		last_used_at,
+		revoked_at,
+		created_at,
+		updated_at
+	FROM
+		api_keys
+	ORDER BY
+		created_at DESC
+	`
+	rows, err := r.db.QueryxContext(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.orm(rows)
+}
+
+func (r apiKeysRepository) FetchApiKeyByHash(ctx context.Context, keyHash string) (*models.ApiKey, error) {
+	sql := `
+	SELECT
+		id,
+		name,
+		prefix,
+		key_hash,
+		scopes,
+		created_by,
+		last_used_at,
+		revoked_at,
+		created_at,
+		updated_at
+	FROM
+		api_keys
+	WHERE
+		key_hash = $1::text
+	`
+	rows, err := r.db.QueryxContext(ctx, sql, keyHash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	apiKeys, err := r.orm(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(apiKeys) == 0 {
+		return nil, errors.New(constants.ERROR_API_KEY_NOT_FOUND)
+	}
+
+	return apiKeys[0], nil
+}
+
+func (r apiKeysRepository) RevokeApiKey(ctx context.Context, id *uuid.UUID, revokedAt *helper.Timestamp) error {
+	sql := `
+		UPDATE api_keys
+		SET
+			revoked_at = $2::timestamp,
+			updated_at = $2::timestamp
+		WHERE
+			id = $1::uuid
+		AND
+			revoked_at IS NULL
+	`
+	result, err := r.db.ExecContext(ctx, sql, id, revokedAt)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New(constants.ERROR_API_KEY_NOT_FOUND)
+	}
+
+	return nil
+}
+
+func (r apiKeysRepository) UpdateLastUsedAt(ctx context.Context, id *uuid.UUID, lastUsedAt *helper.Timestamp) error {
+	sql := `
+		UPDATE api_keys
+		SET
+			last_used_at = $2::timestamp
+		WHERE
+			id = $1::uuid
+	`
+	_, err := r.db.ExecContext(ctx, sql, id, lastUsedAt)
+	return err
+}
+
+func (r apiKeysRepository) orm(rows *sqlx.Rows) ([]*models.ApiKey, error) {
+	mapper, err := orm.Orm(new(models.ApiKey), rows, orm.NewMapperOption())
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.GetData().([]*models.ApiKey), nil
+}
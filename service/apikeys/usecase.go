@@ -0,0 +1,15 @@
+package apikeys
+
+import (
+	"context"
+	"github/pheethy/todo/models"
+
+	"github.com/gofrs/uuid"
+)
+
+type ApiKeysUsecase interface {
+	CreateApiKey(ctx context.Context, req *models.ApiKeyCreate, createdBy string) (*models.ApiKeyIssued, error)
+	FetchListApiKey(ctx context.Context) ([]*models.ApiKey, error)
+	RevokeApiKey(ctx context.Context, id *uuid.UUID) error
+	Authenticate(ctx context.Context, key string) (*models.ApiKey, error)
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github/pheethy/todo/auth"
+	"github/pheethy/todo/config"
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/helper"
+	"github/pheethy/todo/models"
+	"github/pheethy/todo/service/apikeys"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type apiKeysUsecase struct {
+	cfg         config.IJwtConfig
+	apiKeysRepo apikeys.ApiKeysRepository
+}
+
+func NewApiKeysUsecase(cfg config.IJwtConfig, apiKeysRepo apikeys.ApiKeysRepository) apikeys.ApiKeysUsecase {
+	return apiKeysUsecase{cfg: cfg, apiKeysRepo: apiKeysRepo}
+}
+
+/* CreateApiKey key เต็มถูกคืนครั้งเดียวตรงนี้ ที่ฐานข้อมูลเก็บไว้แค่ hash */
+func (u apiKeysUsecase) CreateApiKey(ctx context.Context, req *models.ApiKeyCreate, createdBy string) (*models.ApiKeyIssued, error) {
+	var scopes = make([]string, 0, len(req.Scopes))
+	for _, scope := range req.Scopes {
+		if !models.IsValidApiKeyScope(scope) {
+			return nil, errors.New(constants.ERROR_API_KEY_SCOPE_INVALID)
+		}
+		scopes = append(scopes, scope)
+	}
+
+	key, prefix, err := auth.NewApiKey()
+	if err != nil {
+		return nil, err
+	}
+
+	var now = helper.NewTimestampFromTime(time.Now())
+	var apiKey = &models.ApiKey{
+		Name:      strings.TrimSpace(req.Name),
+		Prefix:    prefix,
+		KeyHash:   auth.HashApiKey(u.cfg, key),
+		Scopes:    strings.Join(scopes, " "),
+		CreatedBy: createdBy,
+	}
+	apiKey.NewId()
+	apiKey.SetCreatedAt(now)
+	apiKey.SetUpatedAt(now)
+
+	if err := u.apiKeysRepo.CreateApiKey(ctx, apiKey); err != nil {
+		return nil, err
+	}
+
+	return &models.ApiKeyIssued{ApiKey: apiKey, Key: key}, nil
+}
+
+func (u apiKeysUsecase) FetchListApiKey(ctx context.Context) ([]*models.ApiKey, error) {
+	return u.apiKeysRepo.FetchListApiKey(ctx)
+}
+
+func (u apiKeysUsecase) RevokeApiKey(ctx context.Context, id *uuid.UUID) error {
+	var now = helper.NewTimestampFromTime(time.Now())
+	return u.apiKeysRepo.RevokeApiKey(ctx, id, &now)
+}
+
+/* Authenticate หา key จาก hash ตรวจว่ายังไม่ถูก revoke แล้วบันทึกเวลาที่ใช้ล่าสุด */
+func (u apiKeysUsecase) Authenticate(ctx context.Context, key string) (*models.ApiKey, error) {
+	apiKey, err := u.apiKeysRepo.FetchApiKeyByHash(ctx, auth.HashApiKey(u.cfg, key))
+	if err != nil {
+		if err.Error() == constants.ERROR_API_KEY_NOT_FOUND {
+			return nil, errors.New(constants.ERROR_API_KEY_INVALID)
+		}
+		return nil, err
+	}
+	if apiKey.IsRevoked() {
+		return nil, errors.New(constants.ERROR_API_KEY_REVOKED)
+	}
+
+	var now = helper.NewTimestampFromTime(time.Now())
+	if err := u.apiKeysRepo.UpdateLastUsedAt(ctx, apiKey.Id, &now); err != nil {
+		/* บันทึกเวลาไม่สำเร็จไม่ควรทำให้ request ของ client ล้ม */
+		log.Printf("update api key last used at failed: %v", err)
+	} else {
+		apiKey.LastUsedAt = &now
+	}
+
+	return apiKey, nil
+}
@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github/pheethy/todo/auth"
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/helper"
+	"github/pheethy/todo/models"
+	"github/pheethy/todo/service/apikeys/mocks"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type jwtConfig struct{}
+
+func (jwtConfig) AdminKey() []byte      { return []byte("76jfqJzzPJKhyKjk") }
+func (jwtConfig) SecretKey() []byte     { return []byte("6t7hkJmVr5U2L5WL") }
+func (jwtConfig) ApiKey() []byte        { return []byte("v2UeyF3xDtCMmNCG") }
+func (jwtConfig) AccessExpiresAt() int  { return 60 }
+func (jwtConfig) RefreshExpiresAt() int { return 120 }
+
+func TestCreateApiKey(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		repo := mocks.NewApiKeysRepository(t)
+		repo.On("CreateApiKey", mock.Anything, mock.AnythingOfType("*models.ApiKey")).Return(nil)
+
+		us := NewApiKeysUsecase(jwtConfig{}, repo)
+		issued, err := us.CreateApiKey(context.Background(), &models.ApiKeyCreate{
+			Name:   "ci-bot",
+			Scopes: []string{constants.API_KEY_SCOPE_TASKS_READ, constants.API_KEY_SCOPE_TASKS_WRITE},
+		}, "admin001")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "tasks:read tasks:write", issued.Scopes)
+		assert.Equal(t, issued.Key[:len(issued.Prefix)], issued.Prefix)
+		assert.Equal(t, auth.HashApiKey(jwtConfig{}, issued.Key), issued.KeyHash)
+		assert.NotEqual(t, issued.Key, issued.KeyHash)
+	})
+
+	t.Run("invalid_scope", func(t *testing.T) {
+		repo := mocks.NewApiKeysRepository(t)
+
+		us := NewApiKeysUsecase(jwtConfig{}, repo)
+		_, err := us.CreateApiKey(context.Background(), &models.ApiKeyCreate{
+			Name:   "ci-bot",
+			Scopes: []string{"tasks:delete"},
+		}, "admin001")
+
+		assert.EqualError(t, err, constants.ERROR_API_KEY_SCOPE_INVALID)
+	})
+}
+
+func TestAuthenticate(t *testing.T) {
+	id := uuid.FromStringOrNil("3b0f3f25-5a43-4a6a-9d1c-0f1c1a4f3e11")
+	key := "todo_0123456789abcdefghijklmnopqrstuvwxyzABCDE"
+	keyHash := auth.HashApiKey(jwtConfig{}, key)
+
+	t.Run("success", func(t *testing.T) {
+		repo := mocks.NewApiKeysRepository(t)
+		repo.On("FetchApiKeyByHash", mock.Anything, keyHash).Return(&models.ApiKey{Id: &id, Name: "ci-bot", Scopes: "tasks:read"}, nil)
+		repo.On("UpdateLastUsedAt", mock.Anything, &id, mock.AnythingOfType("*helper.Timestamp")).Return(nil)
+
+		apiKey, err := NewApiKeysUsecase(jwtConfig{}, repo).Authenticate(context.Background(), key)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, apiKey.LastUsedAt)
+		assert.True(t, apiKey.HasScope(constants.API_KEY_SCOPE_TASKS_READ))
+		assert.False(t, apiKey.HasScope(constants.API_KEY_SCOPE_TASKS_WRITE))
+		assert.Equal(t, "apikey:ci-bot", apiKey.Claims().Username)
+	})
+
+	t.Run("unknown_key", func(t *testing.T) {
+		repo := mocks.NewApiKeysRepository(t)
+		repo.On("FetchApiKeyByHash", mock.Anything, keyHash).Return(nil, errors.New(constants.ERROR_API_KEY_NOT_FOUND))
+
+		_, err := NewApiKeysUsecase(jwtConfig{}, repo).Authenticate(context.Background(), key)
+
+		assert.EqualError(t, err, constants.ERROR_API_KEY_INVALID)
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		var revokedAt = helper.NewTimestampFromTime(time.Now())
+		repo := mocks.NewApiKeysRepository(t)
+		repo.On("FetchApiKeyByHash", mock.Anything, keyHash).Return(&models.ApiKey{Id: &id, Name: "ci-bot", RevokedAt: &revokedAt}, nil)
+
+		_, err := NewApiKeysUsecase(jwtConfig{}, repo).Authenticate(context.Background(), key)
+
+		assert.EqualError(t, err, constants.ERROR_API_KEY_REVOKED)
+	})
+}
@@ -0,0 +1,16 @@
+package orders
+
+import (
+	"fmt"
+	"github/pheethy/todo/models"
+)
+
+/* ErrIllegalTransition ถูกส่งกลับเมื่อเปลี่ยน status ของ order ข้ามลำดับที่กำหนดไว้ */
+type ErrIllegalTransition struct {
+	From models.OrderStatus
+	To   models.OrderStatus
+}
+
+func (e ErrIllegalTransition) Error() string {
+	return fmt.Sprintf("can not move order from '%s' to '%s'", e.From, e.To)
+}
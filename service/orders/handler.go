@@ -0,0 +1,12 @@
+package orders
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type OrdersHandler interface {
+	PlaceOrder(c *gin.Context)
+	FetchListOrder(c *gin.Context)
+	FetchOrderById(c *gin.Context)
+	UpdateOrderStatus(c *gin.Context)
+}
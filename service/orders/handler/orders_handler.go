@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/models"
+	"github/pheethy/todo/service/orders"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+)
+
+type ordersHandler struct {
+	ordersUs orders.OrdersUsecase
+}
+
+func NewOrdersHandler(ordersUs orders.OrdersUsecase) orders.OrdersHandler {
+	return ordersHandler{ordersUs: ordersUs}
+}
+
+func (h ordersHandler) PlaceOrder(c *gin.Context) {
+	var ctx = c.Request.Context()
+	var req = new(models.OrderPlace)
+
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("can't binding data: %v", err))
+		return
+	}
+
+	order, err := h.ordersUs.PlaceOrder(ctx, req)
+	if err != nil {
+		c.JSON(h.statusFromError(err), err.Error())
+		return
+	}
+
+	resp := map[string]interface{}{
+		"message":     "Created.",
+		"order":       order,
+		"total_price": order.TotalPrice(),
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (h ordersHandler) FetchListOrder(c *gin.Context) {
+	var ctx = c.Request.Context()
+	var filter = &models.OrderFilter{Status: models.OrderStatus(c.Query("status"))}
+	var paginator = models.NewPaginator(cast.ToInt(c.Query("page")), cast.ToInt(c.Query("per_page")))
+
+	orders, err := h.ordersUs.FetchListOrder(ctx, filter, paginator)
+	if err != nil {
+		c.JSON(h.statusFromError(err), err.Error())
+		return
+	}
+
+	resp := map[string]interface{}{
+		"orders":    orders,
+		"paginator": paginator,
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h ordersHandler) FetchOrderById(c *gin.Context) {
+	var ctx = c.Request.Context()
+
+	order, err := h.ordersUs.FetchOrderById(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(h.statusFromError(err), err.Error())
+		return
+	}
+
+	resp := map[string]interface{}{
+		"order":       order,
+		"total_price": order.TotalPrice(),
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h ordersHandler) UpdateOrderStatus(c *gin.Context) {
+	var ctx = c.Request.Context()
+	var req = struct {
+		Status models.OrderStatus `json:"status" binding:"required"`
+	}{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("can't binding data: %v", err))
+		return
+	}
+
+	order, err := h.ordersUs.UpdateOrderStatus(ctx, c.Param("id"), req.Status)
+	if err != nil {
+		c.JSON(h.statusFromError(err), err.Error())
+		return
+	}
+
+	resp := map[string]interface{}{
+		"message": "Updated.",
+		"order":   order,
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h ordersHandler) statusFromError(err error) int {
+	if errors.As(err, &orders.ErrIllegalTransition{}) {
+		return http.StatusUnprocessableEntity
+	}
+	switch err.Error() {
+	case constants.ERROR_ORDER_NOT_FOUND:
+		return http.StatusNotFound
+	case constants.ERROR_ORDER_STATUS_WAS_CHANGED:
+		return http.StatusConflict
+	case constants.ERROR_ORDER_STATUS_INVALID, constants.ERROR_PRODUCT_NOT_FOUND:
+		return http.StatusBadRequest
+	case constants.ERROR_TOKEN_MISSING:
+		return http.StatusUnauthorized
+	case constants.ERROR_PERMISSION_DENIED:
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
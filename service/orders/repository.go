@@ -0,0 +1,13 @@
+package orders
+
+import (
+	"context"
+	"github/pheethy/todo/models"
+)
+
+type OrdersRepository interface {
+	PlaceOrder(ctx context.Context, order *models.Order) error
+	FetchListOrder(ctx context.Context, filter *models.OrderFilter, paginator *models.Paginator) ([]*models.Order, error)
+	FetchOrderById(ctx context.Context, id string) (*models.Order, error)
+	UpdateOrderStatus(ctx context.Context, order *models.Order, fromStatus models.OrderStatus) error
+}
@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/models"
+	"github/pheethy/todo/orm"
+	"github/pheethy/todo/service/orders"
+	"strings"
+
+	"github.com/BlackMocca/sqlx"
+)
+
+type ordersRepository struct {
+	db *sqlx.DB
+}
+
+func NewOrdersRepository(db *sqlx.DB) orders.OrdersRepository {
+	return ordersRepository{db: db}
+}
+
+/* PlaceOrder สร้าง order และ snapshot product ทุกชิ้นลง products_orders ใน transaction เดียว */
+func (r ordersRepository) PlaceOrder(ctx context.Context, order *models.Order) error {
+	orderSql := `
+		INSERT INTO orders (
+			user_id,
+			contact,
+			address,
+			status,
+			created_at,
+			updated_at
+		)
+		VALUES(
+			$1::text,
+			$2::text,
+			$3::text,
+			$4::order_status,
+			$5::timestamp,
+			$6::timestamp
+		)
+		RETURNING id
+	`
+	productSql := `
+		INSERT INTO products_orders (
+			id,
+			order_id,
+			qty,
+			product
+		)
+		VALUES(
+			$1::uuid,
+			$2::text,
+			$3::int,
+			$4::jsonb
+		)
+	`
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	if err := tx.QueryRowxContext(ctx, orderSql,
+		order.UserId,
+		order.Contact,
+		order.Address,
+		order.Status,
+		order.CreatedAt,
+		order.UpdatedAt,
+	).Scan(&order.Id); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for _, item := range order.Products {
+		snapshot, err := json.Marshal(item.Product)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		item.OrderId = order.Id
+
+		if _, err := tx.ExecContext(ctx, productSql,
+			item.Id,
+			item.OrderId,
+			item.Qty,
+			string(snapshot),
+		); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r ordersRepository) FetchListOrder(ctx context.Context, filter *models.OrderFilter, paginator *models.Paginator) ([]*models.Order, error) {
+	conds, args := r.orderFilterConds(filter)
+
+	mapper, err := r.fetchOrders(ctx, conds, args, paginator.PerPage, paginator.Offset())
+	if err != nil {
+		return nil, err
+	}
+	paginator.SetTotalRows(mapper.GetPaginateTotal())
+
+	return mapper.GetData().([]*models.Order), nil
+}
+
+func (r ordersRepository) FetchOrderById(ctx context.Context, id string) (*models.Order, error) {
+	mapper, err := r.fetchOrders(ctx, []string{"orders.id = $1::text"}, []interface{}{id}, 1, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := mapper.GetData().([]*models.Order)
+	if len(orders) == 0 {
+		return nil, errors.New(constants.ERROR_ORDER_NOT_FOUND)
+	}
+
+	return orders[0], nil
+}
+
+/* fetchOrders แบ่งหน้าที่ตาราง orders ก่อนแล้วค่อย join products_orders เหมือน products repository */
+func (r ordersRepository) fetchOrders(ctx context.Context, conds []string, args []interface{}, limit int, offset int) (orm.Mapper, error) {
+	args = append(args, limit, offset)
+	sql := fmt.Sprintf(`
+	SELECT
+		orders.%s,
+		orders.id "orders.id",
+		orders.user_id "orders.user_id",
+		orders.contact "orders.contact",
+		orders.address "orders.address",
+		orders.transfer_slip "orders.transfer_slip",
+		orders.status "orders.status",
+		orders.created_at "orders.created_at",
+		orders.updated_at "orders.updated_at",
+		products_orders.id "products_orders.id",
+		products_orders.order_id "products_orders.order_id",
+		products_orders.qty "products_orders.qty",
+		products_orders.product "products_orders.product"
+	FROM (
+		SELECT
+			orders.*,
+			COUNT(*) OVER() AS %s
+		FROM
+			orders
+		WHERE
+			%s
+		ORDER BY
+			orders.created_at DESC, orders.id DESC
+		LIMIT $%d OFFSET $%d
+	) orders
+	LEFT JOIN
+		products_orders ON products_orders.order_id = orders.id
+	ORDER BY
+		orders.created_at DESC, orders.id DESC, products_orders.id
+	`,
+		orm.PAGINATE_COLUMN_NAME,
+		orm.PAGINATE_COLUMN_NAME,
+		strings.Join(conds, " AND "),
+		len(args)-1, len(args),
+	)
+	rows, err := r.db.QueryxContext(ctx, sql, args...)
+	if err != nil {
+		return orm.Mapper{}, err
+	}
+	defer rows.Close()
+
+	return orm.Orm(new(models.Order), rows, orm.NewMapperOption())
+}
+
+func (r ordersRepository) orderFilterConds(filter *models.OrderFilter) ([]string, []interface{}) {
+	var conds = []string{"TRUE"}
+	var args = make([]interface{}, 0)
+	var addCond = func(cond string, arg interface{}) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if filter.UserId != "" {
+		addCond("orders.user_id = $%d::text", filter.UserId)
+	}
+	if filter.Status != "" {
+		addCond("orders.status = $%d::order_status", filter.Status)
+	}
+
+	return conds, args
+}
+
+/* UpdateOrderStatus เปลี่ยน status เฉพาะเมื่อ status ใน database ยังเป็น fromStatus อยู่ */
+func (r ordersRepository) UpdateOrderStatus(ctx context.Context, order *models.Order, fromStatus models.OrderStatus) error {
+	sql := `
+		UPDATE orders
+		SET
+			status = $2::order_status,
+			updated_at = $4::timestamp
+		WHERE
+			id = $1::text
+		AND
+			status = $3::order_status
+	`
+	result, err := r.db.ExecContext(ctx, sql,
+		order.Id,
+		order.Status,
+		fromStatus,
+		order.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New(constants.ERROR_ORDER_STATUS_WAS_CHANGED)
+	}
+
+	return nil
+}
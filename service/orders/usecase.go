@@ -0,0 +1,13 @@
+package orders
+
+import (
+	"context"
+	"github/pheethy/todo/models"
+)
+
+type OrdersUsecase interface {
+	PlaceOrder(ctx context.Context, req *models.OrderPlace) (*models.Order, error)
+	FetchListOrder(ctx context.Context, filter *models.OrderFilter, paginator *models.Paginator) ([]*models.Order, error)
+	FetchOrderById(ctx context.Context, id string) (*models.Order, error)
+	UpdateOrderStatus(ctx context.Context, id string, toStatus models.OrderStatus) (*models.Order, error)
+}
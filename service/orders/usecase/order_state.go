@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/models"
+	"github/pheethy/todo/service/orders"
+)
+
+/* orderTransitions คือ status ถัดไปที่ order แต่ละ status ย้ายไปได้ completed และ canceled เป็นสถานะสุดท้าย */
+var orderTransitions = map[models.OrderStatus][]models.OrderStatus{
+	constants.ORDER_STATUS_WAITING:  {constants.ORDER_STATUS_SHIPPING, constants.ORDER_STATUS_CANCELED},
+	constants.ORDER_STATUS_SHIPPING: {constants.ORDER_STATUS_COMPLETED},
+}
+
+func validateTransition(from models.OrderStatus, to models.OrderStatus) error {
+	for _, next := range orderTransitions[from] {
+		if next == to {
+			return nil
+		}
+	}
+	return orders.ErrIllegalTransition{From: from, To: to}
+}
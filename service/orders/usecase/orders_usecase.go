@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github/pheethy/todo/auth"
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/helper"
+	"github/pheethy/todo/models"
+	"github/pheethy/todo/service/orders"
+	"github/pheethy/todo/service/products"
+	"strings"
+	"time"
+)
+
+type ordersUsecase struct {
+	ordersRepo   orders.OrdersRepository
+	productsRepo products.ProductsRepository
+}
+
+func NewOrdersUsecase(ordersRepo orders.OrdersRepository, productsRepo products.ProductsRepository) orders.OrdersUsecase {
+	return ordersUsecase{ordersRepo: ordersRepo, productsRepo: productsRepo}
+}
+
+/* PlaceOrder product ที่สั่งซ้ำกันจะถูกรวม qty เป็นรายการเดียว */
+func (u ordersUsecase) PlaceOrder(ctx context.Context, req *models.OrderPlace) (*models.Order, error) {
+	user := auth.UserFromContext(ctx)
+	if user == nil {
+		return nil, errors.New(constants.ERROR_TOKEN_MISSING)
+	}
+
+	var now = helper.NewTimestampFromTime(time.Now())
+	var order = &models.Order{
+		UserId:   user.Id,
+		Contact:  strings.TrimSpace(req.Contact),
+		Address:  strings.TrimSpace(req.Address),
+		Status:   constants.ORDER_STATUS_WAITING,
+		Products: make([]*models.ProductsOrder, 0, len(req.Products)),
+	}
+	order.SetCreatedAt(now)
+	order.SetUpatedAt(now)
+
+	var items = make(map[string]*models.ProductsOrder)
+	for _, p := range req.Products {
+		if item, ok := items[p.ProductId]; ok {
+			item.Qty += p.Qty
+			continue
+		}
+		product, err := u.productsRepo.FetchProductById(ctx, p.ProductId)
+		if err != nil {
+			return nil, err
+		}
+		item := &models.ProductsOrder{Qty: p.Qty, Product: product}
+		item.NewId()
+		items[p.ProductId] = item
+		order.Products = append(order.Products, item)
+	}
+
+	if err := u.ordersRepo.PlaceOrder(ctx, order); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+/* FetchListOrder ลูกค้าเห็นเฉพาะ order ของตัวเอง admin เห็นทั้งหมด */
+func (u ordersUsecase) FetchListOrder(ctx context.Context, filter *models.OrderFilter, paginator *models.Paginator) ([]*models.Order, error) {
+	if filter.Status != "" && !models.IsValidOrderStatus(filter.Status) {
+		return nil, errors.New(constants.ERROR_ORDER_STATUS_INVALID)
+	}
+	user := auth.UserFromContext(ctx)
+	if user == nil {
+		return nil, errors.New(constants.ERROR_PERMISSION_DENIED)
+	}
+	if !auth.IsAdmin(user) {
+		filter.UserId = user.Id
+	}
+	return u.ordersRepo.FetchListOrder(ctx, filter, paginator)
+}
+
+func (u ordersUsecase) FetchOrderById(ctx context.Context, id string) (*models.Order, error) {
+	user := auth.UserFromContext(ctx)
+	if user == nil {
+		return nil, errors.New(constants.ERROR_PERMISSION_DENIED)
+	}
+
+	order, err := u.ordersRepo.FetchOrderById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !auth.IsAdmin(user) && order.UserId != user.Id {
+		/* ไม่บอกว่ามี order นี้อยู่ถ้าไม่ใช่เจ้าของ */
+		return nil, errors.New(constants.ERROR_ORDER_NOT_FOUND)
+	}
+	return order, nil
+}
+
+/* UpdateOrderStatus admin ย้าย status ได้ตามลำดับ ลูกค้ายกเลิกได้เฉพาะ order ของตัวเองที่ยังรออยู่ */
+func (u ordersUsecase) UpdateOrderStatus(ctx context.Context, id string, toStatus models.OrderStatus) (*models.Order, error) {
+	if !models.IsValidOrderStatus(toStatus) {
+		return nil, errors.New(constants.ERROR_ORDER_STATUS_INVALID)
+	}
+
+	order, err := u.FetchOrderById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user := auth.UserFromContext(ctx); !auth.IsAdmin(user) && toStatus != constants.ORDER_STATUS_CANCELED {
+		return nil, errors.New(constants.ERROR_PERMISSION_DENIED)
+	}
+	if err := validateTransition(order.Status, toStatus); err != nil {
+		return nil, err
+	}
+
+	var fromStatus = order.Status
+	order.Status = toStatus
+	order.SetUpatedAt(helper.NewTimestampFromTime(time.Now()))
+
+	if err := u.ordersRepo.UpdateOrderStatus(ctx, order, fromStatus); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
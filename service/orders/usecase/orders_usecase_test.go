@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github/pheethy/todo/auth"
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/models"
+	"github/pheethy/todo/service/orders"
+	"github/pheethy/todo/service/orders/mocks"
+	productsMocks "github/pheethy/todo/service/products/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	customer = &models.UserClaims{Id: "U000001", Username: "customer001", RoleId: constants.ROLE_CUSTOMER}
+	admin    = &models.UserClaims{Id: "U000002", Username: "admin001", RoleId: constants.ROLE_ADMIN}
+)
+
+func TestPlaceOrder(t *testing.T) {
+	coffee := &models.Product{Id: "P000001", Title: "Coffee", Price: 150}
+	steak := &models.Product{Id: "P000002", Title: "Steak", Price: 200}
+
+	ordersRepo := mocks.NewOrdersRepository(t)
+	productsRepo := productsMocks.NewProductsRepository(t)
+	productsRepo.On("FetchProductById", mock.Anything, "P000001").Return(coffee, nil).Once()
+	productsRepo.On("FetchProductById", mock.Anything, "P000002").Return(steak, nil).Once()
+	ordersRepo.On("PlaceOrder", mock.Anything, mock.AnythingOfType("*models.Order")).Return(nil)
+
+	ctx := auth.WithUser(context.Background(), customer)
+	order, err := NewOrdersUsecase(ordersRepo, productsRepo).PlaceOrder(ctx, &models.OrderPlace{
+		Contact: "0812345678",
+		Address: "Bangkok",
+		Products: []*models.OrderPlaceProduct{
+			{ProductId: "P000001", Qty: 1},
+			{ProductId: "P000002", Qty: 1},
+			{ProductId: "P000001", Qty: 2},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, customer.Id, order.UserId)
+	assert.Equal(t, models.OrderStatus(constants.ORDER_STATUS_WAITING), order.Status)
+	assert.Len(t, order.Products, 2)
+	assert.Equal(t, 3, order.Products[0].Qty)
+	assert.Equal(t, float64(650), order.TotalPrice())
+}
+
+func TestUpdateOrderStatus(t *testing.T) {
+	cases := map[string]struct {
+		user     *models.UserClaims
+		from     models.OrderStatus
+		to       models.OrderStatus
+		expected error
+	}{
+		"admin_ship":       {user: admin, from: constants.ORDER_STATUS_WAITING, to: constants.ORDER_STATUS_SHIPPING},
+		"customer_cancel":  {user: customer, from: constants.ORDER_STATUS_WAITING, to: constants.ORDER_STATUS_CANCELED},
+		"customer_ship":    {user: customer, from: constants.ORDER_STATUS_WAITING, to: constants.ORDER_STATUS_SHIPPING, expected: errors.New(constants.ERROR_PERMISSION_DENIED)},
+		"cancel_shipping":  {user: admin, from: constants.ORDER_STATUS_SHIPPING, to: constants.ORDER_STATUS_CANCELED, expected: orders.ErrIllegalTransition{From: constants.ORDER_STATUS_SHIPPING, To: constants.ORDER_STATUS_CANCELED}},
+		"reopen_completed": {user: admin, from: constants.ORDER_STATUS_COMPLETED, to: constants.ORDER_STATUS_WAITING, expected: orders.ErrIllegalTransition{From: constants.ORDER_STATUS_COMPLETED, To: constants.ORDER_STATUS_WAITING}},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ordersRepo := mocks.NewOrdersRepository(t)
+			ordersRepo.On("FetchOrderById", mock.Anything, "O000001").Return(&models.Order{Id: "O000001", UserId: customer.Id, Status: tc.from}, nil)
+			if tc.expected == nil {
+				ordersRepo.On("UpdateOrderStatus", mock.Anything, mock.AnythingOfType("*models.Order"), tc.from).Return(nil)
+			}
+
+			ctx := auth.WithUser(context.Background(), tc.user)
+			order, err := NewOrdersUsecase(ordersRepo, productsMocks.NewProductsRepository(t)).UpdateOrderStatus(ctx, "O000001", tc.to)
+
+			if tc.expected != nil {
+				assert.EqualError(t, err, tc.expected.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.to, order.Status)
+		})
+	}
+
+	t.Run("other_customer_order", func(t *testing.T) {
+		ordersRepo := mocks.NewOrdersRepository(t)
+		ordersRepo.On("FetchOrderById", mock.Anything, "O000001").Return(&models.Order{Id: "O000001", UserId: "U000009", Status: constants.ORDER_STATUS_WAITING}, nil)
+
+		ctx := auth.WithUser(context.Background(), customer)
+		_, err := NewOrdersUsecase(ordersRepo, productsMocks.NewProductsRepository(t)).UpdateOrderStatus(ctx, "O000001", constants.ORDER_STATUS_CANCELED)
+
+		assert.EqualError(t, err, constants.ERROR_ORDER_NOT_FOUND)
+	})
+}
+
+func TestFetchOrderWithoutUser(t *testing.T) {
+	us := NewOrdersUsecase(mocks.NewOrdersRepository(t), productsMocks.NewProductsRepository(t))
+
+	_, err := us.FetchOrderById(context.Background(), "O000001")
+	assert.EqualError(t, err, constants.ERROR_PERMISSION_DENIED)
+
+	_, err = us.FetchListOrder(context.Background(), &models.OrderFilter{}, models.NewPaginator(1, 10))
+	assert.EqualError(t, err, constants.ERROR_PERMISSION_DENIED)
+}
@@ -0,0 +1,15 @@
+package products
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type ProductsHandler interface {
+	FetchListProduct(c *gin.Context)
+	FetchProductById(c *gin.Context)
+	CreateProduct(c *gin.Context)
+	UpdateProduct(c *gin.Context)
+	DeleteProduct(c *gin.Context)
+	UploadImages(c *gin.Context)
+	DeleteImage(c *gin.Context)
+}
@@ -0,0 +1,220 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/models"
+	"github/pheethy/todo/service/products"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gofrs/uuid"
+	"github.com/spf13/cast"
+)
+
+type productsHandler struct {
+	productsUs products.ProductsUsecase
+}
+
+func NewProductsHandler(productsUs products.ProductsUsecase) products.ProductsHandler {
+	return productsHandler{productsUs: productsUs}
+}
+
+func (h productsHandler) FetchListProduct(c *gin.Context) {
+	var ctx = c.Request.Context()
+
+	filter, err := h.productFilterFromQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+	var paginator = models.NewPaginator(cast.ToInt(c.Query("page")), cast.ToInt(c.Query("per_page")))
+
+	products, err := h.productsUs.FetchListProduct(ctx, filter, paginator)
+	if err != nil {
+		c.JSON(h.statusFromError(err), err.Error())
+		return
+	}
+
+	resp := map[string]interface{}{
+		"products":  products,
+		"paginator": paginator,
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h productsHandler) FetchProductById(c *gin.Context) {
+	var ctx = c.Request.Context()
+
+	product, err := h.productsUs.FetchProductById(ctx, c.Param("id"))
+	if err != nil {
+		c.JSON(h.statusFromError(err), err.Error())
+		return
+	}
+
+	resp := map[string]interface{}{
+		"product": product,
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h productsHandler) CreateProduct(c *gin.Context) {
+	var ctx = c.Request.Context()
+	var req = new(models.ProductCreate)
+
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("can't binding data: %v", err))
+		return
+	}
+
+	product, err := h.productsUs.CreateProduct(ctx, req)
+	if err != nil {
+		c.JSON(h.statusFromError(err), err.Error())
+		return
+	}
+
+	resp := map[string]interface{}{
+		"message": "Created.",
+		"product": product,
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (h productsHandler) UpdateProduct(c *gin.Context) {
+	var ctx = c.Request.Context()
+	var req = new(models.ProductUpdate)
+
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("can't binding data: %v", err))
+		return
+	}
+
+	product, err := h.productsUs.UpdateProduct(ctx, c.Param("id"), req)
+	if err != nil {
+		c.JSON(h.statusFromError(err), err.Error())
+		return
+	}
+
+	resp := map[string]interface{}{
+		"message": "Updated.",
+		"product": product,
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h productsHandler) DeleteProduct(c *gin.Context) {
+	var ctx = c.Request.Context()
+	var id = c.Param("id")
+
+	if err := h.productsUs.DeleteProduct(ctx, id); err != nil {
+		c.JSON(h.statusFromError(err), err.Error())
+		return
+	}
+
+	resp := map[string]interface{}{
+		"message": "Deleted.",
+		"id":      id,
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+/* UploadImages รับไฟล์จาก multipart field "files" ได้หลายไฟล์ */
+func (h productsHandler) UploadImages(c *gin.Context) {
+	var ctx = c.Request.Context()
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("can't binding data: %v", err))
+		return
+	}
+
+	images, err := h.productsUs.UploadImages(ctx, c.Param("id"), form.File["files"])
+	if err != nil {
+		c.JSON(h.statusFromError(err), err.Error())
+		return
+	}
+
+	resp := map[string]interface{}{
+		"message": "Uploaded.",
+		"images":  images,
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (h productsHandler) DeleteImage(c *gin.Context) {
+	var ctx = c.Request.Context()
+
+	imageId, err := uuid.FromString(c.Param("image_id"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, constants.ERROR_IMAGE_NOT_FOUND)
+		return
+	}
+
+	if err := h.productsUs.DeleteImage(ctx, c.Param("id"), &imageId); err != nil {
+		c.JSON(h.statusFromError(err), err.Error())
+		return
+	}
+
+	resp := map[string]interface{}{
+		"message": "Deleted.",
+		"id":      imageId,
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+/* productFilterFromQuery อ่าน category_id, min_price, max_price, sort และ order จาก query string */
+func (h productsHandler) productFilterFromQuery(c *gin.Context) (*models.ProductFilter, error) {
+	var filter = models.NewProductFilter()
+	var parsePrice = func(key string) (*float64, error) {
+		val := strings.TrimSpace(c.Query(key))
+		if val == "" {
+			return nil, nil
+		}
+		price, err := cast.ToFloat64E(val)
+		if err != nil {
+			return nil, errors.New(constants.ERROR_PRODUCT_PRICE_RANGE_INVALID)
+		}
+		return &price, nil
+	}
+
+	filter.CategoryId = cast.ToInt(c.Query("category_id"))
+	if sortBy := c.Query("sort"); sortBy != "" {
+		filter.SortBy = sortBy
+	}
+	if direction := c.Query("order"); direction != "" {
+		filter.SortDirection = direction
+	}
+
+	var err error
+	if filter.MinPrice, err = parsePrice("min_price"); err != nil {
+		return nil, err
+	}
+	if filter.MaxPrice, err = parsePrice("max_price"); err != nil {
+		return nil, err
+	}
+
+	return filter, nil
+}
+
+func (h productsHandler) statusFromError(err error) int {
+	switch err.Error() {
+	case constants.ERROR_PRODUCT_NOT_FOUND, constants.ERROR_IMAGE_NOT_FOUND:
+		return http.StatusNotFound
+	case constants.ERROR_PRODUCT_SORT_INVALID, constants.ERROR_PRODUCT_PRICE_RANGE_INVALID, constants.ERROR_PRODUCT_CATEGORY_NOT_FOUND_SERVICE, constants.ERROR_FILE_REQUIRED:
+		return http.StatusBadRequest
+	case constants.ERROR_FILE_TOO_LARGE:
+		return http.StatusRequestEntityTooLarge
+	case constants.ERROR_FILE_TYPE_INVALID:
+		return http.StatusUnsupportedMediaType
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,20 @@
+package products
+
+import (
+	"context"
+	"github/pheethy/todo/models"
+
+	"github.com/gofrs/uuid"
+)
+
+type ProductsRepository interface {
+	FetchListProduct(ctx context.Context, filter *models.ProductFilter, paginator *models.Paginator) ([]*models.Product, error)
+	FetchProductById(ctx context.Context, id string) (*models.Product, error)
+	FetchProductByTitle(ctx context.Context, title string) (*models.Product, error)
+	CreateProduct(ctx context.Context, product *models.Product) error
+	UpdateProduct(ctx context.Context, product *models.Product) error
+	DeleteProduct(ctx context.Context, id string) error
+	CreateImage(ctx context.Context, image *models.Image) error
+	FetchImageById(ctx context.Context, productId string, id *uuid.UUID) (*models.Image, error)
+	DeleteImage(ctx context.Context, id *uuid.UUID) error
+}
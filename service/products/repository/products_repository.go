@@ -0,0 +1,374 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/models"
+	"github/pheethy/todo/orm"
+	"github/pheethy/todo/service/products"
+	"strings"
+
+	"github.com/BlackMocca/sqlx"
+	"github.com/gofrs/uuid"
+)
+
+type productsRepository struct {
+	db *sqlx.DB
+}
+
+func NewProductsRepository(db *sqlx.DB) products.ProductsRepository {
+	return productsRepository{db: db}
+}
+
+func (r productsRepository) FetchListProduct(ctx context.Context, filter *models.ProductFilter, paginator *models.Paginator) ([]*models.Product, error) {
+	conds, args := r.productFilterConds(filter)
+
+	mapper, err := r.fetchProducts(ctx, conds, args, filter.SortBy, filter.SortDirection, paginator.PerPage, paginator.Offset())
+	if err != nil {
+		return nil, err
+	}
+	paginator.SetTotalRows(mapper.GetPaginateTotal())
+
+	return mapper.GetData().([]*models.Product), nil
+}
+
+func (r productsRepository) FetchProductById(ctx context.Context, id string) (*models.Product, error) {
+	mapper, err := r.fetchProducts(ctx, []string{"products.id = $1::text"}, []interface{}{id}, "created_at", "desc", 1, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	products := mapper.GetData().([]*models.Product)
+	if len(products) == 0 {
+		return nil, errors.New(constants.ERROR_PRODUCT_NOT_FOUND)
+	}
+
+	return products[0], nil
+}
+
+/* FetchProductByTitle title ไม่ได้ unique ถ้ามีหลาย product ชื่อซ้ำกันจะคืนตัวที่สร้างก่อน */
+func (r productsRepository) FetchProductByTitle(ctx context.Context, title string) (*models.Product, error) {
+	mapper, err := r.fetchProducts(ctx, []string{"products.title = $1::text"}, []interface{}{title}, "created_at", "asc", 1, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	products := mapper.GetData().([]*models.Product)
+	if len(products) == 0 {
+		return nil, errors.New(constants.ERROR_PRODUCT_NOT_FOUND)
+	}
+
+	return products[0], nil
+}
+
+/*
+fetchProducts แบ่งหน้าที่ตาราง products ก่อนใน subquery แล้วค่อย join categories และ images
+เพื่อให้ LIMIT/OFFSET และ total_row นับเป็นจำนวน product ไม่ใช่จำนวนแถวหลัง join
+*/
+func (r productsRepository) fetchProducts(ctx context.Context, conds []string, args []interface{}, sortBy string, sortDirection string, limit int, offset int) (orm.Mapper, error) {
+	args = append(args, limit, offset)
+	sql := fmt.Sprintf(`
+	SELECT
+		products.%s,
+		%s,
+		%s,
+		%s
+	FROM (
+		SELECT
+			products.*,
+			COUNT(*) OVER() AS %s
+		FROM
+			products
+		WHERE
+			%s
+		ORDER BY
+			products.%s %s, products.id %s
+		LIMIT $%d OFFSET $%d
+	) products
+	LEFT JOIN (
+		SELECT
+			products_categories.product_id,
+			categories.id,
+			categories.title
+		FROM
+			products_categories
+		JOIN
+			categories ON categories.id = products_categories.category_id
+	) categories ON categories.product_id = products.id
+	LEFT JOIN
+		images ON images.product_id = products.id
+	ORDER BY
+		products.%s %s, products.id %s, categories.id, images.created_at
+	`,
+		orm.PAGINATE_COLUMN_NAME,
+		orm.GetSelector(new(models.Product)),
+		orm.GetSelector(new(models.Category)),
+		orm.GetSelector(new(models.Image)),
+		orm.PAGINATE_COLUMN_NAME,
+		strings.Join(conds, " AND "),
+		sortBy, sortDirection, sortDirection,
+		len(args)-1, len(args),
+		sortBy, sortDirection, sortDirection,
+	)
+	rows, err := r.db.QueryxContext(ctx, sql, args...)
+	if err != nil {
+		return orm.Mapper{}, err
+	}
+	defer rows.Close()
+
+	return orm.Orm(new(models.Product), rows, orm.NewMapperOption())
+}
+
+func (r productsRepository) productFilterConds(filter *models.ProductFilter) ([]string, []interface{}) {
+	var conds = []string{"TRUE"}
+	var args = make([]interface{}, 0)
+	var addCond = func(cond string, arg interface{}) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if filter.CategoryId != 0 {
+		addCond(`EXISTS (
+			SELECT 1 FROM products_categories
+			WHERE products_categories.product_id = products.id
+			AND products_categories.category_id = $%d::int
+		)`, filter.CategoryId)
+	}
+	if filter.MinPrice != nil {
+		addCond("products.price >= $%d::float", *filter.MinPrice)
+	}
+	if filter.MaxPrice != nil {
+		addCond("products.price <= $%d::float", *filter.MaxPrice)
+	}
+
+	return conds, args
+}
+
+func (r productsRepository) CreateProduct(ctx context.Context, product *models.Product) error {
+	sql := `
+		INSERT INTO products (
+			title,
+			description,
+			price,
+			created_at,
+			updated_at
+		)
+		VALUES(
+			$1::text,
+			$2::text,
+			$3::float,
+			$4::timestamp,
+			$5::timestamp
+		)
+		RETURNING id
+	`
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	if err := tx.QueryRowxContext(ctx, sql,
+		product.Title,
+		product.Description,
+		product.Price,
+		product.CreatedAt,
+		product.UpdatedAt,
+	).Scan(&product.Id); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := r.insertCategories(ctx, tx, product); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+/* UpdateProduct แก้ไข product และแทนที่ category ทั้งหมดด้วย product.Categories */
+func (r productsRepository) UpdateProduct(ctx context.Context, product *models.Product) error {
+	sql := `
+		UPDATE products
+		SET
+			title = $2::text,
+			description = $3::text,
+			price = $4::float,
+			updated_at = $5::timestamp
+		WHERE
+			id = $1::text
+	`
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, sql,
+		product.Id,
+		product.Title,
+		product.Description,
+		product.Price,
+		product.UpdatedAt,
+	)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		tx.Rollback()
+		if err != nil {
+			return err
+		}
+		return errors.New(constants.ERROR_PRODUCT_NOT_FOUND)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM products_categories WHERE product_id = $1::text`, product.Id); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := r.insertCategories(ctx, tx, product); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r productsRepository) insertCategories(ctx context.Context, tx *sqlx.Tx, product *models.Product) error {
+	sql := `
+		INSERT INTO products_categories (
+			product_id,
+			category_id
+		)
+		VALUES(
+			$1::text,
+			$2::int
+		)
+	`
+	for _, categoryId := range product.CategoryIds() {
+		if _, err := tx.ExecContext(ctx, sql, product.Id, categoryId); err != nil {
+			if strings.Contains(err.Error(), constants.ERROR_PRODUCT_CATEGORY_NOT_FOUND) {
+				return errors.New(constants.ERROR_PRODUCT_CATEGORY_NOT_FOUND_SERVICE)
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+/* DeleteProduct ลบ images และ products_categories ที่อ้างถึง product ก่อน เพราะ foreign key ไม่ได้ ON DELETE CASCADE */
+func (r productsRepository) DeleteProduct(ctx context.Context, id string) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	for _, sql := range []string{
+		`DELETE FROM images WHERE product_id = $1::text`,
+		`DELETE FROM products_categories WHERE product_id = $1::text`,
+	} {
+		if _, err := tx.ExecContext(ctx, sql, id); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM products WHERE id = $1::text`, id)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		tx.Rollback()
+		if err != nil {
+			return err
+		}
+		return errors.New(constants.ERROR_PRODUCT_NOT_FOUND)
+	}
+
+	return tx.Commit()
+}
+
+func (r productsRepository) CreateImage(ctx context.Context, image *models.Image) error {
+	sql := `
+		INSERT INTO images (
+			id,
+			filename,
+			url,
+			product_id,
+			created_at,
+			updated_at
+		)
+		VALUES(
+			$1::uuid,
+			$2::text,
+			$3::text,
+			$4::text,
+			$5::timestamp,
+			$6::timestamp
+		)
+	`
+	_, err := r.db.ExecContext(ctx, sql,
+		image.Id,
+		image.Filename,
+		image.Url,
+		image.ProductId,
+		image.CreatedAt,
+		image.UpdatedAt,
+	)
+	return err
+}
+
+func (r productsRepository) FetchImageById(ctx context.Context, productId string, id *uuid.UUID) (*models.Image, error) {
+	sql := `
+	SELECT
+		id,
+		filename,
+		url,
+		product_id,
+		created_at,
+		updated_at
+	FROM
+		images
+	WHERE
+		id = $1::uuid
+	AND
+		product_id = $2::text
+	`
+	rows, err := r.db.QueryxContext(ctx, sql, id, productId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	mapper, err := orm.Orm(new(models.Image), rows, orm.NewMapperOption())
+	if err != nil {
+		return nil, err
+	}
+
+	images := mapper.GetData().([]*models.Image)
+	if len(images) == 0 {
+		return nil, errors.New(constants.ERROR_IMAGE_NOT_FOUND)
+	}
+
+	return images[0], nil
+}
+
+func (r productsRepository) DeleteImage(ctx context.Context, id *uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM images WHERE id = $1::uuid`, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New(constants.ERROR_IMAGE_NOT_FOUND)
+	}
+
+	return nil
+}
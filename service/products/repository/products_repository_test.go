@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/helper"
+	"github/pheethy/todo/models"
+	"testing"
+	"time"
+
+	"github.com/BlackMocca/sqlx"
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/DATA-DOG/go-sqlmock.v2"
+)
+
+var productColumns = []string{
+	"total_row",
+	"products.id", "products.title", "products.description", "products.price", "products.created_at", "products.updated_at",
+	"categories.id", "categories.title", "categories.product_id",
+	"images.id", "images.filename", "images.url", "images.product_id", "images.created_at", "images.updated_at",
+}
+
+func TestFetchListProduct(t *testing.T) {
+	now := helper.NewTimestampFromTime(time.Now())
+	image1 := uuid.FromStringOrNil("c580fe73-afb3-47d1-a9df-eed24fdaea9b")
+	image2 := uuid.FromStringOrNil("43bcd3fa-6f7f-4251-b196-f30ad4ea625e")
+
+	t.Run("success_with_categories_and_images", func(t *testing.T) {
+		db, sqlMock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		sqlxDB := sqlx.NewDb(db, "sqlmock")
+		defer db.Close()
+
+		rows := sqlmock.NewRows(productColumns).
+			AddRow(2, "P000001", "Coffee", "Just a food & beverage product", 150.0, now, now, 1, "food & beverage", "P000001", image1.String(), "fb1_1.jpg", "https://example.com/fb1_1.jpg", "P000001", now, now).
+			AddRow(2, "P000001", "Coffee", "Just a food & beverage product", 150.0, now, now, 1, "food & beverage", "P000001", image2.String(), "fb1_2.jpg", "https://example.com/fb1_2.jpg", "P000001", now, now).
+			AddRow(2, "P000003", "Shirt", "Just a fashion product", 590.0, now, now, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+		sqlMock.ExpectQuery(`SELECT (.+) FROM (.+) products`).WillReturnRows(rows)
+
+		repo := NewProductsRepository(sqlxDB)
+		paginator := models.NewPaginator(1, 20)
+		epProducts, err := repo.FetchListProduct(context.Background(), models.NewProductFilter(), paginator)
+
+		assert.NoError(t, err)
+		assert.Len(t, epProducts, 2)
+		assert.Equal(t, 2, paginator.TotalRows)
+
+		assert.Equal(t, "P000001", epProducts[0].Id)
+		assert.Len(t, epProducts[0].Categories, 1)
+		assert.Equal(t, "food & beverage", epProducts[0].Categories[0].Title)
+		assert.Len(t, epProducts[0].Images, 2)
+
+		assert.Equal(t, "P000003", epProducts[1].Id)
+		assert.Empty(t, epProducts[1].Categories)
+		assert.Empty(t, epProducts[1].Images)
+	})
+}
+
+func TestFetchProductById(t *testing.T) {
+	t.Run("not_found", func(t *testing.T) {
+		db, sqlMock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		sqlxDB := sqlx.NewDb(db, "sqlmock")
+		defer db.Close()
+
+		sqlMock.ExpectQuery(`SELECT (.+) FROM (.+) products`).WithArgs("P999999", 1, 0).WillReturnRows(sqlmock.NewRows(productColumns))
+
+		repo := NewProductsRepository(sqlxDB)
+		product, err := repo.FetchProductById(context.Background(), "P999999")
+
+		assert.EqualError(t, err, constants.ERROR_PRODUCT_NOT_FOUND)
+		assert.Nil(t, product)
+	})
+}
@@ -0,0 +1,19 @@
+package products
+
+import (
+	"context"
+	"github/pheethy/todo/models"
+	"mime/multipart"
+
+	"github.com/gofrs/uuid"
+)
+
+type ProductsUsecase interface {
+	FetchListProduct(ctx context.Context, filter *models.ProductFilter, paginator *models.Paginator) ([]*models.Product, error)
+	FetchProductById(ctx context.Context, id string) (*models.Product, error)
+	CreateProduct(ctx context.Context, req *models.ProductCreate) (*models.Product, error)
+	UpdateProduct(ctx context.Context, id string, req *models.ProductUpdate) (*models.Product, error)
+	DeleteProduct(ctx context.Context, id string) error
+	UploadImages(ctx context.Context, productId string, files []*multipart.FileHeader) ([]*models.Image, error)
+	DeleteImage(ctx context.Context, productId string, id *uuid.UUID) error
+}
@@ -0,0 +1,203 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github/pheethy/todo/config"
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/helper"
+	"github/pheethy/todo/models"
+	"github/pheethy/todo/service/products"
+	"github/pheethy/todo/storage"
+	"log"
+	"mime/multipart"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type productsUsecase struct {
+	cfg          config.IAppConfig
+	productsRepo products.ProductsRepository
+	storage      storage.Storage
+}
+
+func NewProductsUsecase(cfg config.IAppConfig, productsRepo products.ProductsRepository, storage storage.Storage) products.ProductsUsecase {
+	return productsUsecase{cfg: cfg, productsRepo: productsRepo, storage: storage}
+}
+
+func (u productsUsecase) FetchListProduct(ctx context.Context, filter *models.ProductFilter, paginator *models.Paginator) ([]*models.Product, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	return u.productsRepo.FetchListProduct(ctx, filter, paginator)
+}
+
+func (u productsUsecase) FetchProductById(ctx context.Context, id string) (*models.Product, error) {
+	return u.productsRepo.FetchProductById(ctx, id)
+}
+
+func (u productsUsecase) CreateProduct(ctx context.Context, req *models.ProductCreate) (*models.Product, error) {
+	var now = helper.NewTimestampFromTime(time.Now())
+	var product = &models.Product{
+		Title:       strings.TrimSpace(req.Title),
+		Description: req.Description,
+		Price:       req.Price,
+		Categories:  newCategories(req.CategoryIds),
+	}
+	product.SetCreatedAt(now)
+	product.SetUpatedAt(now)
+
+	if err := u.productsRepo.CreateProduct(ctx, product); err != nil {
+		return nil, err
+	}
+
+	return u.productsRepo.FetchProductById(ctx, product.Id)
+}
+
+func (u productsUsecase) UpdateProduct(ctx context.Context, id string, req *models.ProductUpdate) (*models.Product, error) {
+	product, err := u.productsRepo.FetchProductById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != nil {
+		product.Title = strings.TrimSpace(*req.Title)
+	}
+	if req.Description != nil {
+		product.Description = *req.Description
+	}
+	if req.Price != nil {
+		product.Price = *req.Price
+	}
+	if req.CategoryIds != nil {
+		product.Categories = newCategories(*req.CategoryIds)
+	}
+	product.SetUpatedAt(helper.NewTimestampFromTime(time.Now()))
+
+	if err := u.productsRepo.UpdateProduct(ctx, product); err != nil {
+		return nil, err
+	}
+
+	return u.productsRepo.FetchProductById(ctx, product.Id)
+}
+
+/* DeleteProduct ลบไฟล์รูปของ product ออกจาก storage หลังลบข้อมูลใน database สำเร็จแล้ว */
+func (u productsUsecase) DeleteProduct(ctx context.Context, id string) error {
+	product, err := u.productsRepo.FetchProductById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := u.productsRepo.DeleteProduct(ctx, id); err != nil {
+		return err
+	}
+	for _, image := range product.Images {
+		u.deleteImageFile(ctx, image)
+	}
+	return nil
+}
+
+/*
+UploadImages ตรวจขนาดและชนิดไฟล์ทุกไฟล์ก่อนอัปโหลด
+ถ้าไฟล์ใดอัปโหลดไม่สำเร็จ รูปที่อัปโหลดไปแล้วใน request เดียวกันจะถูกลบทิ้ง
+*/
+func (u productsUsecase) UploadImages(ctx context.Context, productId string, files []*multipart.FileHeader) ([]*models.Image, error) {
+	if len(files) == 0 {
+		return nil, errors.New(constants.ERROR_FILE_REQUIRED)
+	}
+	if _, err := u.productsRepo.FetchProductById(ctx, productId); err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		if file.Size > int64(u.cfg.FileLimit()) {
+			return nil, errors.New(constants.ERROR_FILE_TOO_LARGE)
+		}
+	}
+
+	var images = make([]*models.Image, 0, len(files))
+	for _, file := range files {
+		image, err := u.uploadImage(ctx, productId, file)
+		if err != nil {
+			for _, uploaded := range images {
+				if err := u.productsRepo.DeleteImage(ctx, uploaded.Id); err != nil {
+					log.Printf("rollback image %s failed: %v", uploaded.Id, err)
+				}
+				u.deleteImageFile(ctx, uploaded)
+			}
+			return nil, err
+		}
+		images = append(images, image)
+	}
+
+	return images, nil
+}
+
+func (u productsUsecase) uploadImage(ctx context.Context, productId string, file *multipart.FileHeader) (*models.Image, error) {
+	src, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	contentType, ext, err := storage.ValidateImage(src, file.Size, u.cfg.FileLimit())
+	if err != nil {
+		return nil, err
+	}
+
+	var now = helper.NewTimestampFromTime(time.Now())
+	var image = &models.Image{ProductId: productId}
+	image.NewId()
+	image.Filename = image.Id.String() + ext
+	image.SetCreatedAt(now)
+	image.SetUpatedAt(now)
+
+	if image.Url, err = u.storage.Upload(ctx, imageKey(image), src, contentType); err != nil {
+		return nil, err
+	}
+	if err := u.productsRepo.CreateImage(ctx, image); err != nil {
+		u.deleteImageFile(ctx, image)
+		return nil, err
+	}
+
+	return image, nil
+}
+
+func (u productsUsecase) DeleteImage(ctx context.Context, productId string, id *uuid.UUID) error {
+	image, err := u.productsRepo.FetchImageById(ctx, productId, id)
+	if err != nil {
+		return err
+	}
+	if err := u.productsRepo.DeleteImage(ctx, image.Id); err != nil {
+		return err
+	}
+	u.deleteImageFile(ctx, image)
+	return nil
+}
+
+/* deleteImageFile ลบไฟล์ไม่สำเร็จจะแค่ log ไว้ เพราะข้อมูลใน database ถูกลบไปแล้ว */
+func (u productsUsecase) deleteImageFile(ctx context.Context, image *models.Image) {
+	if err := u.storage.Delete(ctx, imageKey(image)); err != nil {
+		log.Printf("delete image file %s failed: %v", imageKey(image), err)
+	}
+}
+
+/* imageKey path ของไฟล์รูปใน storage: products/<product_id>/<filename> */
+func imageKey(image *models.Image) string {
+	return path.Join("products", image.ProductId, image.Filename)
+}
+
+/* newCategories ตัด category id ที่ซ้ำออก เพราะ products_categories ไม่มี unique constraint */
+func newCategories(ids []int) []*models.Category {
+	var seen = make(map[int]bool, len(ids))
+	var categories = make([]*models.Category, 0, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		categories = append(categories, &models.Category{Id: id})
+	}
+	return categories
+}
@@ -0,0 +1,16 @@
+package todo
+
+import (
+	"fmt"
+	"github/pheethy/todo/models"
+)
+
+/* ErrIllegalTransition ถูกส่งกลับเมื่อเปลี่ยน status ของ task ข้ามลำดับที่กำหนดไว้ */
+type ErrIllegalTransition struct {
+	From models.TaskStatus
+	To   models.TaskStatus
+}
+
+func (e ErrIllegalTransition) Error() string {
+	return fmt.Sprintf("can not move task from '%s' to '%s'", e.From, e.To)
+}
@@ -6,5 +6,18 @@ import (
 
 type TodoHandler interface {
 	CreateTask(c *gin.Context)
+	ImportTasks(c *gin.Context)
 	FetchListTodo(c *gin.Context)
-}
\ No newline at end of file
+	SearchTask(c *gin.Context)
+	FetchTaskById(c *gin.Context)
+	UpdateTask(c *gin.Context)
+	DeleteTask(c *gin.Context)
+	FetchListTrash(c *gin.Context)
+	PurgeTrash(c *gin.Context)
+	RestoreTask(c *gin.Context)
+	TransitionTask(c *gin.Context)
+	UploadAttachment(c *gin.Context)
+	FetchListAttachment(c *gin.Context)
+	DownloadAttachment(c *gin.Context)
+	DeleteAttachment(c *gin.Context)
+}
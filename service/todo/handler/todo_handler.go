@@ -1,14 +1,20 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
+	"github/pheethy/todo/auth"
+	"github/pheethy/todo/constants"
 	"github/pheethy/todo/helper"
 	"github/pheethy/todo/models"
 	"github/pheethy/todo/service/todo"
+	"mime"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gofrs/uuid"
+	"github.com/spf13/cast"
 )
 
 type todoHandler struct {
@@ -25,17 +31,25 @@ func (h todoHandler) CreateTask(c *gin.Context) {
 	var now = helper.NewTimestampFromTime(time.Now())
 
 	if err := c.ShouldBindJSON(newTask); err != nil {
-		c.AbortWithError(http.StatusInternalServerError, fmt.Errorf("can't binding data: ", err))
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("can't binding data: %v", err))
 		return
 	}
 
+	/* creator_name มาจาก token เสมอ ไม่เชื่อค่าที่ client ส่งมา */
+	user := auth.UserFromContext(ctx)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, constants.ERROR_TOKEN_MISSING)
+		return
+	}
+	newTask.CreatorName = user.Username
+
 	newTask.NewId()
 	newTask.SetCreatedAt(now)
 	newTask.SetUpatedAt(now)
-	newTask.Status = "draft"
+	newTask.Status = constants.TASK_STATUS_DRAFT
 
 	if err := h.todoUs.CreateTask(ctx, newTask); err != nil {
-		c.JSON(http.StatusInternalServerError, err.Error())
+		c.JSON(h.statusFromError(err), err.Error())
 		return
 	}
 
@@ -47,10 +61,223 @@ func (h todoHandler) CreateTask(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+/* ImportTasks นำเข้า task หลายรายการ (admin) ถ้าไม่ระบุ creator_name จะใช้ชื่อของผู้นำเข้า */
+func (h todoHandler) ImportTasks(c *gin.Context) {
+	var ctx = c.Request.Context()
+	var tasks = make([]*models.Task, 0)
+	var now = helper.NewTimestampFromTime(time.Now())
+
+	if err := c.ShouldBindJSON(&tasks); err != nil {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("can't binding data: %v", err))
+		return
+	}
+
+	user := auth.UserFromContext(ctx)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, constants.ERROR_TOKEN_MISSING)
+		return
+	}
+
+	var ids = make([]*uuid.UUID, 0, len(tasks))
+	for _, task := range tasks {
+		if task.Status == "" {
+			task.Status = constants.TASK_STATUS_DRAFT
+		}
+		if !models.IsValidTaskStatus(task.Status) {
+			c.JSON(http.StatusBadRequest, constants.ERROR_TASK_STATUS_INVALID)
+			return
+		}
+		if task.CreatorName == "" {
+			task.CreatorName = user.Username
+		}
+		task.NewId()
+		task.SetCreatedAt(now)
+		task.SetUpatedAt(now)
+		task.DeletedAt = nil
+		ids = append(ids, task.Id)
+	}
+
+	if err := h.todoUs.ImportTasks(ctx, tasks); err != nil {
+		c.JSON(h.statusFromError(err), err.Error())
+		return
+	}
+
+	resp := map[string]interface{}{
+		"message": "Imported.",
+		"ids":     ids,
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
 func (h todoHandler) FetchListTodo(c *gin.Context) {
 	var ctx = c.Request.Context()
+	var paginator = models.NewPaginator(cast.ToInt(c.Query("page")), cast.ToInt(c.Query("per_page")))
+
+	filter, err := h.taskFilterFromQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	/* cursor mode เรียงตาม created_at, id ล่าสุดก่อนเสมอ */
+	if c.Query("paging") == "cursor" || c.Query("cursor") != "" {
+		h.fetchListTodoByCursor(c, filter)
+		return
+	}
+
+	/* หน้าว่างยังตอบ 200 พร้อม total_rows เพื่อให้ client แยก "เลยหน้าสุดท้าย" กับ "ไม่มี task" ได้ */
+	tasks, err := h.todoUs.FetchListTodo(ctx, filter, paginator)
+	if err != nil {
+		c.JSON(h.statusFromError(err), err.Error())
+		return
+	}
+
+	resp := map[string]interface{}{
+		"tasks":     tasks,
+		"paginator": paginator,
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h todoHandler) fetchListTodoByCursor(c *gin.Context, filter *models.TaskFilter) {
+	var ctx = c.Request.Context()
+	var cursor *models.TaskCursor
+
+	if token := c.Query("cursor"); token != "" {
+		decoded, err := models.DecodeTaskCursor(token)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		cursor = decoded
+	}
+	var paginator = models.NewCursorPaginator(cursor, cast.ToInt(c.Query("per_page")))
+
+	tasks, err := h.todoUs.FetchListTodoByCursor(ctx, filter, paginator)
+	if err != nil {
+		c.JSON(h.statusFromError(err), err.Error())
+		return
+	}
+
+	if len(tasks) < 1 {
+		c.JSON(http.StatusNoContent, nil)
+		return
+	}
+
+	resp := map[string]interface{}{
+		"tasks":     tasks,
+		"paginator": paginator,
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h todoHandler) SearchTask(c *gin.Context) {
+	var ctx = c.Request.Context()
 
-	tasks, err := h.todoUs.FetchListTodo(ctx)
+	results, err := h.todoUs.SearchTask(ctx, c.Query("q"), cast.ToInt(c.Query("limit")))
+	if err != nil {
+		c.JSON(h.statusFromError(err), err.Error())
+		return
+	}
+
+	if len(results) < 1 {
+		c.JSON(http.StatusNoContent, nil)
+		return
+	}
+
+	resp := map[string]interface{}{
+		"results": results,
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h todoHandler) FetchTaskById(c *gin.Context) {
+	var ctx = c.Request.Context()
+
+	id, err := h.taskIdFromParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	task, err := h.todoUs.FetchTaskById(ctx, id)
+	if err != nil {
+		c.JSON(h.statusFromError(err), err.Error())
+		return
+	}
+
+	resp := map[string]interface{}{
+		"task": task,
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+/* UpdateTask PUT แทนที่ทุก field ที่แก้ไขได้จึงต้องส่ง task_name ส่วน PATCH แก้ไขเฉพาะ field ที่ส่งมา */
+func (h todoHandler) UpdateTask(c *gin.Context) {
+	var ctx = c.Request.Context()
+	var req = new(models.TaskUpdate)
+
+	id, err := h.taskIdFromParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("can't binding data: %v", err))
+		return
+	}
+	if c.Request.Method == http.MethodPut && req.TaskName == nil {
+		c.JSON(http.StatusBadRequest, constants.ERROR_TASK_NAME_REQUIRED)
+		return
+	}
+
+	task, err := h.todoUs.UpdateTask(ctx, id, req)
+	if err != nil {
+		c.JSON(h.statusFromError(err), err.Error())
+		return
+	}
+
+	resp := map[string]interface{}{
+		"message": "Updated.",
+		"id":      task.Id,
+		"task":    task,
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h todoHandler) DeleteTask(c *gin.Context) {
+	var ctx = c.Request.Context()
+
+	id, err := h.taskIdFromParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.todoUs.DeleteTask(ctx, id); err != nil {
+		c.JSON(h.statusFromError(err), err.Error())
+		return
+	}
+
+	resp := map[string]interface{}{
+		"message": "Deleted.",
+		"id":      id,
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h todoHandler) FetchListTrash(c *gin.Context) {
+	var ctx = c.Request.Context()
+
+	tasks, err := h.todoUs.FetchListTrash(ctx)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, err.Error())
 		return
@@ -67,3 +294,266 @@ func (h todoHandler) FetchListTodo(c *gin.Context) {
 
 	c.JSON(http.StatusOK, resp)
 }
+
+/* PurgeTrash ลบ task ในถังขยะที่ถูกลบมานานกว่า older_than วินาที (ค่าเริ่มต้นลบทั้งหมด) */
+func (h todoHandler) PurgeTrash(c *gin.Context) {
+	var ctx = c.Request.Context()
+	var retention = time.Duration(cast.ToInt64(c.Query("older_than"))) * time.Second
+
+	purged, err := h.todoUs.PurgeTrash(ctx, retention)
+	if err != nil {
+		c.JSON(h.statusFromError(err), err.Error())
+		return
+	}
+
+	resp := map[string]interface{}{
+		"message": "Purged.",
+		"purged":  purged,
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h todoHandler) RestoreTask(c *gin.Context) {
+	var ctx = c.Request.Context()
+
+	id, err := h.taskIdFromParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.todoUs.RestoreTask(ctx, id); err != nil {
+		c.JSON(h.statusFromError(err), err.Error())
+		return
+	}
+
+	resp := map[string]interface{}{
+		"message": "Restored.",
+		"id":      id,
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h todoHandler) TransitionTask(c *gin.Context) {
+	var ctx = c.Request.Context()
+	var req = struct {
+		Status models.TaskStatus `json:"status" binding:"required"`
+	}{}
+
+	id, err := h.taskIdFromParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("can't binding data: %v", err))
+		return
+	}
+
+	user := auth.UserFromContext(ctx)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, constants.ERROR_TOKEN_MISSING)
+		return
+	}
+
+	transition, err := h.todoUs.TransitionTask(ctx, id, req.Status, user.Username)
+	if err != nil {
+		c.JSON(h.statusFromError(err), err.Error())
+		return
+	}
+
+	resp := map[string]interface{}{
+		"message":    "Moved.",
+		"transition": transition,
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+/* UploadAttachment รับไฟล์จาก multipart field "file" ครั้งละหนึ่งไฟล์ */
+func (h todoHandler) UploadAttachment(c *gin.Context) {
+	var ctx = c.Request.Context()
+
+	id, err := h.taskIdFromParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, constants.ERROR_FILE_REQUIRED)
+		return
+	}
+
+	user := auth.UserFromContext(ctx)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, constants.ERROR_TOKEN_MISSING)
+		return
+	}
+
+	attachment, err := h.todoUs.UploadAttachment(ctx, id, file, user.Username)
+	if err != nil {
+		c.JSON(h.statusFromError(err), err.Error())
+		return
+	}
+
+	resp := map[string]interface{}{
+		"message":    "Uploaded.",
+		"attachment": attachment,
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (h todoHandler) FetchListAttachment(c *gin.Context) {
+	var ctx = c.Request.Context()
+
+	id, err := h.taskIdFromParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	attachments, err := h.todoUs.FetchListAttachment(ctx, id)
+	if err != nil {
+		c.JSON(h.statusFromError(err), err.Error())
+		return
+	}
+
+	resp := map[string]interface{}{
+		"attachments": attachments,
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+/* DownloadAttachment ส่งเนื้อไฟล์กลับไปพร้อม checksum ใน header X-Checksum-Sha256 ให้ client ตรวจความถูกต้องได้ */
+func (h todoHandler) DownloadAttachment(c *gin.Context) {
+	var ctx = c.Request.Context()
+
+	id, attachmentId, err := h.attachmentIdFromParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	attachment, file, err := h.todoUs.OpenAttachment(ctx, id, attachmentId)
+	if err != nil {
+		c.JSON(h.statusFromError(err), err.Error())
+		return
+	}
+	defer file.Close()
+
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, file, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}),
+		"X-Checksum-Sha256":   attachment.Checksum,
+	})
+}
+
+func (h todoHandler) DeleteAttachment(c *gin.Context) {
+	var ctx = c.Request.Context()
+
+	id, attachmentId, err := h.attachmentIdFromParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := h.todoUs.DeleteAttachment(ctx, id, attachmentId); err != nil {
+		c.JSON(h.statusFromError(err), err.Error())
+		return
+	}
+
+	resp := map[string]interface{}{
+		"message": "Deleted.",
+		"id":      attachmentId,
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h todoHandler) taskFilterFromQuery(c *gin.Context) (*models.TaskFilter, error) {
+	var filter = models.NewTaskFilter()
+	var parseDate = func(key string, endOfDay bool) (*helper.Timestamp, error) {
+		val := c.Query(key)
+		if val == "" {
+			return nil, nil
+		}
+		ts, err := helper.ParseTimestamp(val)
+		if err != nil {
+			return nil, errors.New(constants.ERROR_TASK_FILTER_DATE_INVALID)
+		}
+		if endOfDay && len(val) == len(helper.DateLayout) {
+			ts = helper.Timestamp(ts.ToTime().Add(24*time.Hour - time.Second))
+		}
+		return &ts, nil
+	}
+
+	filter.Status = models.TaskStatus(c.Query("status"))
+	filter.CreatorName = c.Query("creator_name")
+	if sortBy := c.Query("sort"); sortBy != "" {
+		filter.SortBy = sortBy
+	}
+	if direction := c.Query("order"); direction != "" {
+		filter.SortDirection = direction
+	}
+
+	var err error
+	if filter.CreatedFrom, err = parseDate("created_from", false); err != nil {
+		return nil, err
+	}
+	if filter.CreatedTo, err = parseDate("created_to", true); err != nil {
+		return nil, err
+	}
+	if filter.UpdatedFrom, err = parseDate("updated_from", false); err != nil {
+		return nil, err
+	}
+	if filter.UpdatedTo, err = parseDate("updated_to", true); err != nil {
+		return nil, err
+	}
+
+	return filter, nil
+}
+
+func (h todoHandler) taskIdFromParam(c *gin.Context) (*uuid.UUID, error) {
+	id, err := uuid.FromString(c.Param("id"))
+	if err != nil {
+		return nil, errors.New(constants.ERROR_TASK_ID_INVALID)
+	}
+	return &id, nil
+}
+
+func (h todoHandler) attachmentIdFromParam(c *gin.Context) (*uuid.UUID, *uuid.UUID, error) {
+	id, err := h.taskIdFromParam(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	attachmentId, err := uuid.FromString(c.Param("attachment_id"))
+	if err != nil {
+		return nil, nil, errors.New(constants.ERROR_ATTACHMENT_ID_INVALID)
+	}
+	return id, &attachmentId, nil
+}
+
+func (h todoHandler) statusFromError(err error) int {
+	if errors.As(err, &todo.ErrIllegalTransition{}) {
+		return http.StatusUnprocessableEntity
+	}
+	switch err.Error() {
+	case constants.ERROR_TASK_NOT_FOUND, constants.ERROR_ATTACHMENT_NOT_FOUND:
+		return http.StatusNotFound
+	case constants.ERROR_TASK_STATUS_INVALID, constants.ERROR_TASK_SORT_INVALID, constants.ERROR_TASK_SEARCH_QUERY_REQUIRED, constants.ERROR_TASK_IMPORT_EMPTY, constants.ERROR_TASK_NAME_REQUIRED, constants.ERROR_FILE_REQUIRED:
+		return http.StatusBadRequest
+	case constants.ERROR_PERMISSION_DENIED:
+		return http.StatusForbidden
+	case constants.ERROR_TASKNAME_WAS_DUPLICATE_SERVICE, constants.ERROR_TASK_STATUS_WAS_CHANGED:
+		return http.StatusConflict
+	case constants.ERROR_FILE_TOO_LARGE:
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusInternalServerError
+}
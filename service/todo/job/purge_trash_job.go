@@ -0,0 +1,49 @@
+package job
+
+import (
+	"context"
+	"github/pheethy/todo/auth"
+	"github/pheethy/todo/service/todo"
+	"log"
+	"time"
+)
+
+/* purgeTrashInterval ความถี่ในการตรวจถังขยะ */
+const purgeTrashInterval = time.Hour
+
+type PurgeTrashJob struct {
+	todoUs    todo.TodoUsecase
+	retention time.Duration
+}
+
+func NewPurgeTrashJob(todoUs todo.TodoUsecase, retention time.Duration) PurgeTrashJob {
+	return PurgeTrashJob{todoUs: todoUs, retention: retention}
+}
+
+/* Run ลบ task ที่หมดอายุในถังขยะทุก purgeTrashInterval จนกว่า ctx จะถูก cancel โดยทำงานในนาม auth.SystemUser */
+func (j PurgeTrashJob) Run(ctx context.Context) {
+	ctx = auth.WithSystemUser(ctx)
+	ticker := time.NewTicker(purgeTrashInterval)
+	defer ticker.Stop()
+
+	for {
+		j.purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (j PurgeTrashJob) purge(ctx context.Context) {
+	purged, err := j.todoUs.PurgeTrash(ctx, j.retention)
+	if err != nil {
+		log.Printf("purge trash failed: %v", err)
+		return
+	}
+	if purged > 0 {
+		log.Printf("purge trash: %d task(s) removed", purged)
+	}
+}
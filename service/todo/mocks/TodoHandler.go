@@ -17,11 +17,76 @@ func (_m *TodoHandler) CreateTask(c *gin.Context) {
 	_m.Called(c)
 }
 
+// DeleteAttachment provides a mock function with given fields: c
+func (_m *TodoHandler) DeleteAttachment(c *gin.Context) {
+	_m.Called(c)
+}
+
+// DeleteTask provides a mock function with given fields: c
+func (_m *TodoHandler) DeleteTask(c *gin.Context) {
+	_m.Called(c)
+}
+
+// DownloadAttachment provides a mock function with given fields: c
+func (_m *TodoHandler) DownloadAttachment(c *gin.Context) {
+	_m.Called(c)
+}
+
+// FetchListAttachment provides a mock function with given fields: c
+func (_m *TodoHandler) FetchListAttachment(c *gin.Context) {
+	_m.Called(c)
+}
+
 // FetchListTodo provides a mock function with given fields: c
 func (_m *TodoHandler) FetchListTodo(c *gin.Context) {
 	_m.Called(c)
 }
 
+// FetchListTrash provides a mock function with given fields: c
+func (_m *TodoHandler) FetchListTrash(c *gin.Context) {
+	_m.Called(c)
+}
+
+// FetchTaskById provides a mock function with given fields: c
+func (_m *TodoHandler) FetchTaskById(c *gin.Context) {
+	_m.Called(c)
+}
+
+// ImportTasks provides a mock function with given fields: c
+func (_m *TodoHandler) ImportTasks(c *gin.Context) {
+	_m.Called(c)
+}
+
+// PurgeTrash provides a mock function with given fields: c
+func (_m *TodoHandler) PurgeTrash(c *gin.Context) {
+	_m.Called(c)
+}
+
+// RestoreTask provides a mock function with given fields: c
+func (_m *TodoHandler) RestoreTask(c *gin.Context) {
+	_m.Called(c)
+}
+
+// SearchTask provides a mock function with given fields: c
+func (_m *TodoHandler) SearchTask(c *gin.Context) {
+	_m.Called(c)
+}
+
+// TransitionTask provides a mock function with given fields: c
+func (_m *TodoHandler) TransitionTask(c *gin.Context) {
+	_m.Called(c)
+}
+
+// UpdateTask provides a mock function with given fields: c
+func (_m *TodoHandler) UpdateTask(c *gin.Context) {
+	_m.Called(c)
+}
+
+// UploadAttachment provides a mock function with given fields: c
+func (_m *TodoHandler) UploadAttachment(c *gin.Context) {
+	_m.Called(c)
+}
+
 type mockConstructorTestingTNewTodoHandler interface {
 	mock.TestingT
 	Cleanup(func())
@@ -4,8 +4,10 @@ package mocks
 
 import (
 	context "context"
+	helper "github/pheethy/todo/helper"
 	models "github/pheethy/todo/models"
 
+	uuid "github.com/gofrs/uuid"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -14,6 +16,20 @@ type TodoRepository struct {
 	mock.Mock
 }
 
+// CreateAttachment provides a mock function with given fields: ctx, attachment
+func (_m *TodoRepository) CreateAttachment(ctx context.Context, attachment *models.TaskAttachment) error {
+	ret := _m.Called(ctx, attachment)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TaskAttachment) error); ok {
+		r0 = rf(ctx, attachment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateTask provides a mock function with given fields: ctx, task
 func (_m *TodoRepository) CreateTask(ctx context.Context, task *models.Task) error {
 	ret := _m.Called(ctx, task)
@@ -28,8 +44,142 @@ func (_m *TodoRepository) CreateTask(ctx context.Context, task *models.Task) err
 	return r0
 }
 
-// FetchListTodo provides a mock function with given fields: ctx
-func (_m *TodoRepository) FetchListTodo(ctx context.Context) ([]*models.Task, error) {
+// CreateTasks provides a mock function with given fields: ctx, tasks
+func (_m *TodoRepository) CreateTasks(ctx context.Context, tasks []*models.Task) error {
+	ret := _m.Called(ctx, tasks)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.Task) error); ok {
+		r0 = rf(ctx, tasks)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteAttachment provides a mock function with given fields: ctx, id
+func (_m *TodoRepository) DeleteAttachment(ctx context.Context, id *uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTask provides a mock function with given fields: ctx, id, deletedAt
+func (_m *TodoRepository) DeleteTask(ctx context.Context, id *uuid.UUID, deletedAt *helper.Timestamp) error {
+	ret := _m.Called(ctx, id, deletedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, *helper.Timestamp) error); ok {
+		r0 = rf(ctx, id, deletedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FetchAttachmentById provides a mock function with given fields: ctx, taskId, id
+func (_m *TodoRepository) FetchAttachmentById(ctx context.Context, taskId *uuid.UUID, id *uuid.UUID) (*models.TaskAttachment, error) {
+	ret := _m.Called(ctx, taskId, id)
+
+	var r0 *models.TaskAttachment
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, *uuid.UUID) *models.TaskAttachment); ok {
+		r0 = rf(ctx, taskId, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TaskAttachment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID, *uuid.UUID) error); ok {
+		r1 = rf(ctx, taskId, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchListAttachment provides a mock function with given fields: ctx, taskId
+func (_m *TodoRepository) FetchListAttachment(ctx context.Context, taskId *uuid.UUID) ([]*models.TaskAttachment, error) {
+	ret := _m.Called(ctx, taskId)
+
+	var r0 []*models.TaskAttachment
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) []*models.TaskAttachment); ok {
+		r0 = rf(ctx, taskId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.TaskAttachment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID) error); ok {
+		r1 = rf(ctx, taskId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchListTodo provides a mock function with given fields: ctx, filter, paginator
+func (_m *TodoRepository) FetchListTodo(ctx context.Context, filter *models.TaskFilter, paginator *models.Paginator) ([]*models.Task, error) {
+	ret := _m.Called(ctx, filter, paginator)
+
+	var r0 []*models.Task
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TaskFilter, *models.Paginator) []*models.Task); ok {
+		r0 = rf(ctx, filter, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Task)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.TaskFilter, *models.Paginator) error); ok {
+		r1 = rf(ctx, filter, paginator)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchListTodoByCursor provides a mock function with given fields: ctx, filter, cursor, limit
+func (_m *TodoRepository) FetchListTodoByCursor(ctx context.Context, filter *models.TaskFilter, cursor *models.TaskCursor, limit int) ([]*models.Task, error) {
+	ret := _m.Called(ctx, filter, cursor, limit)
+
+	var r0 []*models.Task
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TaskFilter, *models.TaskCursor, int) []*models.Task); ok {
+		r0 = rf(ctx, filter, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Task)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.TaskFilter, *models.TaskCursor, int) error); ok {
+		r1 = rf(ctx, filter, cursor, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchListTrash provides a mock function with given fields: ctx
+func (_m *TodoRepository) FetchListTrash(ctx context.Context) ([]*models.Task, error) {
 	ret := _m.Called(ctx)
 
 	var r0 []*models.Task
@@ -51,6 +201,147 @@ func (_m *TodoRepository) FetchListTodo(ctx context.Context) ([]*models.Task, er
 	return r0, r1
 }
 
+// FetchTaskById provides a mock function with given fields: ctx, id
+func (_m *TodoRepository) FetchTaskById(ctx context.Context, id *uuid.UUID) (*models.Task, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.Task
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) *models.Task); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Task)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchTaskByName provides a mock function with given fields: ctx, taskName
+func (_m *TodoRepository) FetchTaskByName(ctx context.Context, taskName string) (*models.Task, error) {
+	ret := _m.Called(ctx, taskName)
+
+	var r0 *models.Task
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Task); ok {
+		r0 = rf(ctx, taskName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Task)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, taskName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeTrash provides a mock function with given fields: ctx, deletedBefore
+func (_m *TodoRepository) PurgeTrash(ctx context.Context, deletedBefore *helper.Timestamp) (int64, []string, error) {
+	ret := _m.Called(ctx, deletedBefore)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, *helper.Timestamp) int64); ok {
+		r0 = rf(ctx, deletedBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 []string
+	if rf, ok := ret.Get(1).(func(context.Context, *helper.Timestamp) []string); ok {
+		r1 = rf(ctx, deletedBefore)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *helper.Timestamp) error); ok {
+		r2 = rf(ctx, deletedBefore)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// RestoreTask provides a mock function with given fields: ctx, id, updatedAt
+func (_m *TodoRepository) RestoreTask(ctx context.Context, id *uuid.UUID, updatedAt *helper.Timestamp) error {
+	ret := _m.Called(ctx, id, updatedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, *helper.Timestamp) error); ok {
+		r0 = rf(ctx, id, updatedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchTask provides a mock function with given fields: ctx, q, creatorName, limit
+func (_m *TodoRepository) SearchTask(ctx context.Context, q string, creatorName string, limit int) ([]*models.TaskSearchResult, error) {
+	ret := _m.Called(ctx, q, creatorName, limit)
+
+	var r0 []*models.TaskSearchResult
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) []*models.TaskSearchResult); ok {
+		r0 = rf(ctx, q, creatorName, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.TaskSearchResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int) error); ok {
+		r1 = rf(ctx, q, creatorName, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TransitionTask provides a mock function with given fields: ctx, task, transition
+func (_m *TodoRepository) TransitionTask(ctx context.Context, task *models.Task, transition *models.TaskTransition) error {
+	ret := _m.Called(ctx, task, transition)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Task, *models.TaskTransition) error); ok {
+		r0 = rf(ctx, task, transition)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateTask provides a mock function with given fields: ctx, task
+func (_m *TodoRepository) UpdateTask(ctx context.Context, task *models.Task) error {
+	ret := _m.Called(ctx, task)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Task) error); ok {
+		r0 = rf(ctx, task)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewTodoRepository interface {
 	mock.TestingT
 	Cleanup(func())
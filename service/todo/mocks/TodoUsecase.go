@@ -5,7 +5,11 @@ package mocks
 import (
 	context "context"
 	models "github/pheethy/todo/models"
+	io "io"
+	multipart "mime/multipart"
+	time "time"
 
+	uuid "github.com/gofrs/uuid"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -28,8 +32,105 @@ func (_m *TodoUsecase) CreateTask(ctx context.Context, task *models.Task) error
 	return r0
 }
 
-// FetchListTodo provides a mock function with given fields: ctx
-func (_m *TodoUsecase) FetchListTodo(ctx context.Context) ([]*models.Task, error) {
+// DeleteAttachment provides a mock function with given fields: ctx, taskId, id
+func (_m *TodoUsecase) DeleteAttachment(ctx context.Context, taskId *uuid.UUID, id *uuid.UUID) error {
+	ret := _m.Called(ctx, taskId, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, *uuid.UUID) error); ok {
+		r0 = rf(ctx, taskId, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteTask provides a mock function with given fields: ctx, id
+func (_m *TodoUsecase) DeleteTask(ctx context.Context, id *uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FetchListAttachment provides a mock function with given fields: ctx, taskId
+func (_m *TodoUsecase) FetchListAttachment(ctx context.Context, taskId *uuid.UUID) ([]*models.TaskAttachment, error) {
+	ret := _m.Called(ctx, taskId)
+
+	var r0 []*models.TaskAttachment
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) []*models.TaskAttachment); ok {
+		r0 = rf(ctx, taskId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.TaskAttachment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID) error); ok {
+		r1 = rf(ctx, taskId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchListTodo provides a mock function with given fields: ctx, filter, paginator
+func (_m *TodoUsecase) FetchListTodo(ctx context.Context, filter *models.TaskFilter, paginator *models.Paginator) ([]*models.Task, error) {
+	ret := _m.Called(ctx, filter, paginator)
+
+	var r0 []*models.Task
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TaskFilter, *models.Paginator) []*models.Task); ok {
+		r0 = rf(ctx, filter, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Task)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.TaskFilter, *models.Paginator) error); ok {
+		r1 = rf(ctx, filter, paginator)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchListTodoByCursor provides a mock function with given fields: ctx, filter, paginator
+func (_m *TodoUsecase) FetchListTodoByCursor(ctx context.Context, filter *models.TaskFilter, paginator *models.CursorPaginator) ([]*models.Task, error) {
+	ret := _m.Called(ctx, filter, paginator)
+
+	var r0 []*models.Task
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TaskFilter, *models.CursorPaginator) []*models.Task); ok {
+		r0 = rf(ctx, filter, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Task)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.TaskFilter, *models.CursorPaginator) error); ok {
+		r1 = rf(ctx, filter, paginator)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FetchListTrash provides a mock function with given fields: ctx
+func (_m *TodoUsecase) FetchListTrash(ctx context.Context) ([]*models.Task, error) {
 	ret := _m.Called(ctx)
 
 	var r0 []*models.Task
@@ -51,6 +152,202 @@ func (_m *TodoUsecase) FetchListTodo(ctx context.Context) ([]*models.Task, error
 	return r0, r1
 }
 
+// FetchTaskById provides a mock function with given fields: ctx, id
+func (_m *TodoUsecase) FetchTaskById(ctx context.Context, id *uuid.UUID) (*models.Task, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.Task
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) *models.Task); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Task)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportTasks provides a mock function with given fields: ctx, tasks
+func (_m *TodoUsecase) ImportTasks(ctx context.Context, tasks []*models.Task) error {
+	ret := _m.Called(ctx, tasks)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*models.Task) error); ok {
+		r0 = rf(ctx, tasks)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OpenAttachment provides a mock function with given fields: ctx, taskId, id
+func (_m *TodoUsecase) OpenAttachment(ctx context.Context, taskId *uuid.UUID, id *uuid.UUID) (*models.TaskAttachment, io.ReadCloser, error) {
+	ret := _m.Called(ctx, taskId, id)
+
+	var r0 *models.TaskAttachment
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, *uuid.UUID) *models.TaskAttachment); ok {
+		r0 = rf(ctx, taskId, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TaskAttachment)
+		}
+	}
+
+	var r1 io.ReadCloser
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID, *uuid.UUID) io.ReadCloser); ok {
+		r1 = rf(ctx, taskId, id)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(io.ReadCloser)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *uuid.UUID, *uuid.UUID) error); ok {
+		r2 = rf(ctx, taskId, id)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// PurgeTrash provides a mock function with given fields: ctx, retention
+func (_m *TodoUsecase) PurgeTrash(ctx context.Context, retention time.Duration) (int64, error) {
+	ret := _m.Called(ctx, retention)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) int64); ok {
+		r0 = rf(ctx, retention)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration) error); ok {
+		r1 = rf(ctx, retention)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreTask provides a mock function with given fields: ctx, id
+func (_m *TodoUsecase) RestoreTask(ctx context.Context, id *uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchTask provides a mock function with given fields: ctx, q, limit
+func (_m *TodoUsecase) SearchTask(ctx context.Context, q string, limit int) ([]*models.TaskSearchResult, error) {
+	ret := _m.Called(ctx, q, limit)
+
+	var r0 []*models.TaskSearchResult
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []*models.TaskSearchResult); ok {
+		r0 = rf(ctx, q, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.TaskSearchResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, q, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TransitionTask provides a mock function with given fields: ctx, id, toStatus, movedBy
+func (_m *TodoUsecase) TransitionTask(ctx context.Context, id *uuid.UUID, toStatus models.TaskStatus, movedBy string) (*models.TaskTransition, error) {
+	ret := _m.Called(ctx, id, toStatus, movedBy)
+
+	var r0 *models.TaskTransition
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, models.TaskStatus, string) *models.TaskTransition); ok {
+		r0 = rf(ctx, id, toStatus, movedBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TaskTransition)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID, models.TaskStatus, string) error); ok {
+		r1 = rf(ctx, id, toStatus, movedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateTask provides a mock function with given fields: ctx, id, req
+func (_m *TodoUsecase) UpdateTask(ctx context.Context, id *uuid.UUID, req *models.TaskUpdate) (*models.Task, error) {
+	ret := _m.Called(ctx, id, req)
+
+	var r0 *models.Task
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, *models.TaskUpdate) *models.Task); ok {
+		r0 = rf(ctx, id, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Task)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID, *models.TaskUpdate) error); ok {
+		r1 = rf(ctx, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UploadAttachment provides a mock function with given fields: ctx, taskId, file, uploadedBy
+func (_m *TodoUsecase) UploadAttachment(ctx context.Context, taskId *uuid.UUID, file *multipart.FileHeader, uploadedBy string) (*models.TaskAttachment, error) {
+	ret := _m.Called(ctx, taskId, file, uploadedBy)
+
+	var r0 *models.TaskAttachment
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, *multipart.FileHeader, string) *models.TaskAttachment); ok {
+		r0 = rf(ctx, taskId, file, uploadedBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TaskAttachment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID, *multipart.FileHeader, string) error); ok {
+		r1 = rf(ctx, taskId, file, uploadedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewTodoUsecase interface {
 	mock.TestingT
 	Cleanup(func())
@@ -2,10 +2,28 @@ package todo
 
 import (
 	"context"
+	"github/pheethy/todo/helper"
 	"github/pheethy/todo/models"
+
+	"github.com/gofrs/uuid"
 )
 
 type TodoRepository interface {
 	CreateTask(ctx context.Context, task *models.Task) error
-	FetchListTodo(ctx context.Context) ([]*models.Task, error)
+	CreateTasks(ctx context.Context, tasks []*models.Task) error
+	FetchListTodo(ctx context.Context, filter *models.TaskFilter, paginator *models.Paginator) ([]*models.Task, error)
+	FetchListTodoByCursor(ctx context.Context, filter *models.TaskFilter, cursor *models.TaskCursor, limit int) ([]*models.Task, error)
+	SearchTask(ctx context.Context, q string, creatorName string, limit int) ([]*models.TaskSearchResult, error)
+	FetchTaskById(ctx context.Context, id *uuid.UUID) (*models.Task, error)
+	FetchTaskByName(ctx context.Context, taskName string) (*models.Task, error)
+	UpdateTask(ctx context.Context, task *models.Task) error
+	DeleteTask(ctx context.Context, id *uuid.UUID, deletedAt *helper.Timestamp) error
+	FetchListTrash(ctx context.Context) ([]*models.Task, error)
+	RestoreTask(ctx context.Context, id *uuid.UUID, updatedAt *helper.Timestamp) error
+	PurgeTrash(ctx context.Context, deletedBefore *helper.Timestamp) (int64, []string, error)
+	TransitionTask(ctx context.Context, task *models.Task, transition *models.TaskTransition) error
+	CreateAttachment(ctx context.Context, attachment *models.TaskAttachment) error
+	FetchListAttachment(ctx context.Context, taskId *uuid.UUID) ([]*models.TaskAttachment, error)
+	FetchAttachmentById(ctx context.Context, taskId *uuid.UUID, id *uuid.UUID) (*models.TaskAttachment, error)
+	DeleteAttachment(ctx context.Context, id *uuid.UUID) error
 }
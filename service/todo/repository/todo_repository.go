@@ -3,7 +3,9 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github/pheethy/todo/constants"
+	"github/pheethy/todo/helper"
 	"github/pheethy/todo/models"
 	"github/pheethy/todo/orm"
 	"github/pheethy/todo/service/todo"
@@ -11,6 +13,7 @@ import (
 	"strings"
 
 	"github.com/BlackMocca/sqlx"
+	"github.com/gofrs/uuid"
 )
 
 type todoRepository struct {
@@ -21,58 +24,351 @@ func NewTodoRepository(db *sqlx.DB) todo.TodoRepository {
 	return todoRepository{db: db}
 }
 
+/* insertTasksChunk จำนวน task ต่อคำสั่ง INSERT ของ CreateTasks ไม่ให้เกินจำนวน parameter ที่ postgres รับได้ (65535) */
+const insertTasksChunk = 1000
+
 func (t todoRepository) CreateTask(ctx context.Context, task *models.Task) error {
+	sql, args, err := orm.Insert(task, orm.NewWriteOption())
+	if err != nil {
+		return err
+	}
+
 	tx, err := t.db.Beginx()
 	if err != nil {
 		panic(err)
 	}
 
-	sql := `
-		INSERT INTO todo (
-			id,
-			task_name,
-			status,
-			creator_name,
-			created_at,
-			updated_at,
-			deleted_at
-		)
-		VALUES(
-			$1::uuid,
-			$2::text,
-			$3::todo_status,
-			$4::text,
-			$5::timestamp,
-			$6::timestamp,
-			$7::timestamp
-		)
-	`
-	stmt, err := tx.PreparexContext(ctx, sql)
+	if _, err := tx.ExecContext(ctx, sql, args...); err != nil {
+		tx.Rollback()
+		if strings.Contains(err.Error(), constants.ERROR_TASKNAME_WAS_DUPLICATE) {
+			return errors.New(constants.ERROR_TASKNAME_WAS_DUPLICATE_SERVICE)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (t todoRepository) CreateTasks(ctx context.Context, tasks []*models.Task) error {
+	tx, err := t.db.Beginx()
 	if err != nil {
 		return err
 	}
-	defer stmt.Close()
 
-	if _, err := stmt.ExecContext(ctx,
-		task.Id,
-		task.TaskName,
-		task.Status,
-		task.CreatorName,
-		task.CreatedAt,
-		task.UpdatedAt,
-		task.DeletedAt,
-	); err != nil {
+	for start := 0; start < len(tasks); start += insertTasksChunk {
+		end := start + insertTasksChunk
+		if end > len(tasks) {
+			end = len(tasks)
+		}
+		sql, args, err := orm.InsertMany(tasks[start:end], orm.NewWriteOption())
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, sql, args...); err != nil {
+			tx.Rollback()
+			if strings.Contains(err.Error(), constants.ERROR_TASKNAME_WAS_DUPLICATE) {
+				return errors.New(constants.ERROR_TASKNAME_WAS_DUPLICATE_SERVICE)
+			}
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (t todoRepository) FetchListTodo(ctx context.Context, filter *models.TaskFilter, paginator *models.Paginator) ([]*models.Task, error) {
+	conds, args := t.taskFilterConds(filter)
+
+	args = append(args, paginator.PerPage, paginator.Offset())
+	sql := fmt.Sprintf(`
+	SELECT
+		id,
+		task_name,
+		status,
+		creator_name,
+		created_at,
+		updated_at,
+		COUNT(*) OVER() AS %s
+	FROM
+		todo
+	WHERE
+		%s
+	ORDER BY
+		%s %s, id %s
+	LIMIT $%d OFFSET $%d
+	`,
+		orm.PAGINATE_COLUMN_NAME,
+		strings.Join(conds, " AND "),
+		filter.SortBy, filter.SortDirection, filter.SortDirection,
+		len(args)-1, len(args),
+	)
+	rows, err := t.db.QueryxContext(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	mapper, err := orm.Orm(new(models.Task), rows, orm.NewMapperOption())
+	if err != nil {
+		return nil, err
+	}
+	tasks := mapper.GetData().([]*models.Task)
+
+	/* หน้าที่เลยหน้าสุดท้ายไม่มีแถวให้อ่าน total_row จึงต้องนับแยก */
+	total := mapper.GetPaginateTotal()
+	if len(tasks) == 0 && paginator.Offset() > 0 {
+		if total, err = t.countTodo(ctx, conds, args[:len(args)-2]); err != nil {
+			return nil, err
+		}
+	}
+	paginator.SetTotalRows(total)
+
+	return tasks, nil
+}
+
+func (t todoRepository) countTodo(ctx context.Context, conds []string, args []interface{}) (int, error) {
+	var total int
+	sql := fmt.Sprintf(`
+	SELECT
+		COUNT(*)
+	FROM
+		todo
+	WHERE
+		%s
+	`,
+		strings.Join(conds, " AND "),
+	)
+	if err := t.db.GetContext(ctx, &total, sql, args...); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+/* FetchListTodoByCursor ดึง task แบบ keyset โดยเรียงจาก created_at, id ล่าสุดก่อน และดึงเกินมา 1 แถวเพื่อบอกว่ายังมีหน้าถัดไป */
+func (t todoRepository) FetchListTodoByCursor(ctx context.Context, filter *models.TaskFilter, cursor *models.TaskCursor, limit int) ([]*models.Task, error) {
+	conds, args := t.taskFilterConds(filter)
+	var direction = "DESC"
+
+	if cursor != nil {
+		var operator = "<"
+		if cursor.Prev {
+			operator = ">"
+			direction = "ASC"
+		}
+		args = append(args, cursor.CreatedAt, cursor.Id)
+		conds = append(conds, fmt.Sprintf("(created_at, id) %s ($%d::timestamp, $%d::uuid)", operator, len(args)-1, len(args)))
+	}
+
+	args = append(args, limit+1)
+	sql := fmt.Sprintf(`
+	SELECT
+		id,
+		task_name,
+		status,
+		creator_name,
+		created_at,
+		updated_at
+	FROM
+		todo
+	WHERE
+		%s
+	ORDER BY
+		created_at %s, id %s
+	LIMIT $%d
+	`,
+		strings.Join(conds, " AND "),
+		direction, direction,
+		len(args),
+	)
+	rows, err := t.db.QueryxContext(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	mapper, err := orm.Orm(new(models.Task), rows, orm.NewMapperOption())
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.GetData().([]*models.Task), nil
+}
+
+/* SearchTask ค้นหาจาก search_vector (full-text) และใช้ trigram/ILIKE สำรองสำหรับภาษาที่ไม่มีการเว้นวรรค เช่น ภาษาไทย */
+func (t todoRepository) SearchTask(ctx context.Context, q string, creatorName string, limit int) ([]*models.TaskSearchResult, error) {
+	sql := fmt.Sprintf(`
+	SELECT
+		todo.id "search.id",
+		GREATEST(ts_rank(todo.search_vector, query), similarity(todo.task_name, $1::text)) "search.rank",
+		%s
+	FROM
+		todo,
+		plainto_tsquery('simple', $1::text) query
+	WHERE
+		todo.deleted_at IS NULL
+	AND (
+		todo.search_vector @@ query
+		OR todo.task_name ILIKE '%%' || $2::text || '%%'
+		OR todo.task_name %% $1::text
+	)
+	AND
+		($3::text = '' OR todo.creator_name = $3::text)
+	ORDER BY
+		"search.rank" DESC, todo.created_at DESC
+	LIMIT $4
+	`, orm.GetSelector(new(models.Task)))
+
+	var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	rows, err := t.db.QueryxContext(ctx, sql, q, likeEscaper.Replace(q), creatorName, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	mapper, err := orm.Orm(new(models.TaskSearchResult), rows, orm.NewMapperOption())
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.GetData().([]*models.TaskSearchResult), nil
+}
+
+func (t todoRepository) taskFilterConds(filter *models.TaskFilter) ([]string, []interface{}) {
+	var conds = []string{"deleted_at IS NULL"}
+	var args = make([]interface{}, 0)
+	var addCond = func(cond string, arg interface{}) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if filter.Status != "" {
+		addCond("status = $%d::todo_status", filter.Status)
+	}
+	if filter.CreatorName != "" {
+		addCond("creator_name = $%d::text", filter.CreatorName)
+	}
+	if filter.CreatedFrom != nil {
+		addCond("created_at >= $%d::timestamp", filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		addCond("created_at <= $%d::timestamp", filter.CreatedTo)
+	}
+	if filter.UpdatedFrom != nil {
+		addCond("updated_at >= $%d::timestamp", filter.UpdatedFrom)
+	}
+	if filter.UpdatedTo != nil {
+		addCond("updated_at <= $%d::timestamp", filter.UpdatedTo)
+	}
+
+	return conds, args
+}
+
+func (t todoRepository) FetchTaskById(ctx context.Context, id *uuid.UUID) (*models.Task, error) {
+	return t.fetchTask(ctx, "id = $1::uuid", id)
+}
+
+/* FetchTaskByName task_name เป็น unique จึงใช้เป็น natural key ตอน seed ได้ */
+func (t todoRepository) FetchTaskByName(ctx context.Context, taskName string) (*models.Task, error) {
+	return t.fetchTask(ctx, "task_name = $1::text", taskName)
+}
+
+func (t todoRepository) fetchTask(ctx context.Context, cond string, arg interface{}) (*models.Task, error) {
+	sql := fmt.Sprintf(`
+	SELECT
+		id,
+		task_name,
+		status,
+		creator_name,
+		created_at,
+		updated_at,
+		deleted_at
+	FROM
+		todo
+	WHERE
+		%s
+	AND
+		deleted_at IS NULL
+	`, cond)
+	rows, err := t.db.QueryxContext(ctx, sql, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	mapper, err := orm.Orm(new(models.Task), rows, orm.NewMapperOption())
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := mapper.GetData().([]*models.Task)
+	if len(tasks) == 0 {
+		return nil, errors.New(constants.ERROR_TASK_NOT_FOUND)
+	}
+
+	return tasks[0], nil
+}
+
+func (t todoRepository) UpdateTask(ctx context.Context, task *models.Task) error {
+	tx, err := t.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	option := orm.NewWriteOption().
+		SetColumns("task_name", "updated_at").
+		SetConditions("deleted_at IS NULL")
+	sql, args, err := orm.Update(task, option)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, sql, args...)
+	if err != nil {
+		tx.Rollback()
 		if strings.Contains(err.Error(), constants.ERROR_TASKNAME_WAS_DUPLICATE) {
 			return errors.New(constants.ERROR_TASKNAME_WAS_DUPLICATE_SERVICE)
 		}
-		tx.Rollback()
 		return err
 	}
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		tx.Rollback()
+		if err != nil {
+			return err
+		}
+		return errors.New(constants.ERROR_TASK_NOT_FOUND)
+	}
 
 	return tx.Commit()
 }
 
-func (t todoRepository) FetchListTodo(ctx context.Context) ([]*models.Task, error) {
+func (t todoRepository) DeleteTask(ctx context.Context, id *uuid.UUID, deletedAt *helper.Timestamp) error {
+	sql := `
+		UPDATE todo
+		SET
+			deleted_at = $2::timestamp
+		WHERE
+			id = $1::uuid
+		AND
+			deleted_at IS NULL
+	`
+	result, err := t.db.ExecContext(ctx, sql, id, deletedAt)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New(constants.ERROR_TASK_NOT_FOUND)
+	}
+
+	return nil
+}
+
+func (t todoRepository) FetchListTrash(ctx context.Context) ([]*models.Task, error) {
 	sql := `
 	SELECT
 		id,
@@ -80,24 +376,276 @@ func (t todoRepository) FetchListTodo(ctx context.Context) ([]*models.Task, erro
 		status,
 		creator_name,
 		created_at,
-		updated_at
+		updated_at,
+		deleted_at
 	FROM
 		todo
+	WHERE
+		deleted_at IS NOT NULL
+	ORDER BY
+		deleted_at DESC
 	`
 	rows, err := t.db.QueryxContext(ctx, sql)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 	defer rows.Close()
 
 	mapper, err := orm.Orm(new(models.Task), rows, orm.NewMapperOption())
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	tasks := mapper.GetData().([]*models.Task)
-	
-	return tasks, nil
+	return mapper.GetData().([]*models.Task), nil
+}
+
+func (t todoRepository) RestoreTask(ctx context.Context, id *uuid.UUID, updatedAt *helper.Timestamp) error {
+	sql := `
+		UPDATE todo
+		SET
+			deleted_at = NULL,
+			updated_at = $2::timestamp
+		WHERE
+			id = $1::uuid
+		AND
+			deleted_at IS NOT NULL
+	`
+	result, err := t.db.ExecContext(ctx, sql, id, updatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), constants.ERROR_TASKNAME_WAS_DUPLICATE) {
+			return errors.New(constants.ERROR_TASKNAME_WAS_DUPLICATE_SERVICE)
+		}
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New(constants.ERROR_TASK_NOT_FOUND)
+	}
+
+	return nil
+}
+
+/*
+PurgeTrash ลบ task ในถังขยะถาวรพร้อมไฟล์แนบใน transaction เดียว
+คืน storage key ของไฟล์แนบที่ถูกลบ เพื่อให้ usecase ลบไฟล์ออกจาก storage ต่อ
+*/
+func (t todoRepository) PurgeTrash(ctx context.Context, deletedBefore *helper.Timestamp) (int64, []string, error) {
+	attachmentSql := `
+		DELETE FROM task_attachments
+		USING todo
+		WHERE
+			task_attachments.todo_id = todo.id
+		AND
+			todo.deleted_at IS NOT NULL
+		AND
+			todo.deleted_at < $1::timestamp
+		RETURNING task_attachments.storage_key
+	`
+	taskSql := `
+		DELETE FROM todo
+		WHERE
+			deleted_at IS NOT NULL
+		AND
+			deleted_at < $1::timestamp
+	`
+	tx, err := t.db.Beginx()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var storageKeys = make([]string, 0)
+	if err := tx.SelectContext(ctx, &storageKeys, attachmentSql, deletedBefore); err != nil {
+		tx.Rollback()
+		return 0, nil, err
+	}
+
+	result, err := tx.ExecContext(ctx, taskSql, deletedBefore)
+	if err != nil {
+		tx.Rollback()
+		return 0, nil, err
+	}
+	purged, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return 0, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, nil, err
+	}
+
+	return purged, storageKeys, nil
+}
+
+func (t todoRepository) TransitionTask(ctx context.Context, task *models.Task, transition *models.TaskTransition) error {
+	tx, err := t.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	updateSql := `
+		UPDATE todo
+		SET
+			status = $2::todo_status,
+			updated_at = $4::timestamp
+		WHERE
+			id = $1::uuid
+		AND
+			status = $3::todo_status
+		AND
+			deleted_at IS NULL
+	`
+	result, err := tx.ExecContext(ctx, updateSql,
+		task.Id,
+		transition.ToStatus,
+		transition.FromStatus,
+		task.UpdatedAt,
+	)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		tx.Rollback()
+		if err != nil {
+			return err
+		}
+		return errors.New(constants.ERROR_TASK_STATUS_WAS_CHANGED)
+	}
+
+	insertSql := `
+		INSERT INTO todo_transition (
+			id,
+			todo_id,
+			from_status,
+			to_status,
+			moved_by,
+			moved_at
+		)
+		VALUES(
+			$1::uuid,
+			$2::uuid,
+			$3::todo_status,
+			$4::todo_status,
+			$5::text,
+			$6::timestamp
+		)
+	`
+	if _, err := tx.ExecContext(ctx, insertSql,
+		transition.Id,
+		transition.TaskId,
+		transition.FromStatus,
+		transition.ToStatus,
+		transition.MovedBy,
+		transition.MovedAt,
+	); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (t todoRepository) CreateAttachment(ctx context.Context, attachment *models.TaskAttachment) error {
+	sql := `
+		INSERT INTO task_attachments (
+			id,
+			todo_id,
+			filename,
+			content_type,
+			size,
+			checksum,
+			storage_key,
+			uploaded_by,
+			created_at
+		)
+		VALUES(
+			$1::uuid,
+			$2::uuid,
+			$3::text,
+			$4::text,
+			$5::bigint,
+			$6::text,
+			$7::text,
+			$8::text,
+			$9::timestamp
+		)
+	`
+	_, err := t.db.ExecContext(ctx, sql,
+		attachment.Id,
+		attachment.TaskId,
+		attachment.Filename,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.Checksum,
+		attachment.StorageKey,
+		attachment.UploadedBy,
+		attachment.CreatedAt,
+	)
+	return err
+}
+
+func (t todoRepository) FetchListAttachment(ctx context.Context, taskId *uuid.UUID) ([]*models.TaskAttachment, error) {
+	return t.fetchAttachments(ctx, []string{"todo_id = $1::uuid"}, []interface{}{taskId})
+}
+
+func (t todoRepository) FetchAttachmentById(ctx context.Context, taskId *uuid.UUID, id *uuid.UUID) (*models.TaskAttachment, error) {
+	attachments, err := t.fetchAttachments(ctx, []string{"id = $1::uuid", "todo_id = $2::uuid"}, []interface{}{id, taskId})
+	if err != nil {
+		return nil, err
+	}
+	if len(attachments) == 0 {
+		return nil, errors.New(constants.ERROR_ATTACHMENT_NOT_FOUND)
+	}
+
+	return attachments[0], nil
+}
+
+func (t todoRepository) fetchAttachments(ctx context.Context, conds []string, args []interface{}) ([]*models.TaskAttachment, error) {
+	sql := fmt.Sprintf(`
+	SELECT
+		%s
+	FROM
+		task_attachments
+	WHERE
+		%s
+	ORDER BY
+		task_attachments.created_at, task_attachments.id
+	`,
+		orm.GetSelector(new(models.TaskAttachment)),
+		strings.Join(conds, " AND "),
+	)
+	rows, err := t.db.QueryxContext(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	mapper, err := orm.Orm(new(models.TaskAttachment), rows, orm.NewMapperOption())
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.GetData().([]*models.TaskAttachment), nil
+}
+
+func (t todoRepository) DeleteAttachment(ctx context.Context, id *uuid.UUID) error {
+	result, err := t.db.ExecContext(ctx, `DELETE FROM task_attachments WHERE id = $1::uuid`, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return errors.New(constants.ERROR_ATTACHMENT_NOT_FOUND)
+	}
+
+	return nil
 }
 
 func (t todoRepository) orm(rows *sqlx.Rows) ([]*models.Task, error) {
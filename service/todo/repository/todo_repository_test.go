@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"github/pheethy/todo/constants"
 	"github/pheethy/todo/helper"
 	"github/pheethy/todo/models"
 	"testing"
@@ -44,12 +45,12 @@ func TestFetchTodo(t *testing.T) {
 		defer db.Close()
 
 		rows := sqlmock.NewRows([]string{
-			"id", "task_name", "status", "creator_name", "created_at", "updated_at",
+			"id", "task_name", "status", "creator_name", "created_at", "updated_at", "total_row",
 		})
 
 		for _, task := range tasks {
 			rows.AddRow(
-				task.Id.String(), task.TaskName, task.Status, task.CreatorName, task.CreatedAt, task.UpdatedAt,
+				task.Id.String(), task.TaskName, task.Status, task.CreatorName, task.CreatedAt, task.UpdatedAt, len(tasks),
 			)
 		}
 
@@ -63,9 +64,171 @@ func TestFetchTodo(t *testing.T) {
 		sqlMock.ExpectQuery(sql).WillReturnRows(rows)
 
 		repo := NewTodoRepository(sqlxDB)
-		epTodo, err := repo.FetchListTodo(context.Background())
+		paginator := models.NewPaginator(1, 1)
+		epTodo, err := repo.FetchListTodo(context.Background(), models.NewTaskFilter(), paginator)
 
 		assert.NoError(t, err)
 		assert.NotEmpty(t, epTodo)
+		assert.Equal(t, len(tasks), paginator.TotalRows)
+		assert.Equal(t, 2, paginator.TotalPages)
+	})
+
+	t.Run("page_past_the_end", func(t *testing.T) {
+		db, sqlMock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		sqlxDB := sqlx.NewDb(db, "sqlmock")
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{
+			"id", "task_name", "status", "creator_name", "created_at", "updated_at", "total_row",
+		})
+		sqlMock.ExpectQuery(`SELECT (.+) COUNT\(\*\) OVER\(\)`).WillReturnRows(rows)
+		sqlMock.ExpectQuery(`SELECT\s+COUNT\(\*\)\s+FROM\s+todo`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(len(tasks)))
+
+		repo := NewTodoRepository(sqlxDB)
+		paginator := models.NewPaginator(5, 1)
+		epTodo, err := repo.FetchListTodo(context.Background(), models.NewTaskFilter(), paginator)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, epTodo)
+		assert.Empty(t, epTodo)
+		assert.Equal(t, len(tasks), paginator.TotalRows)
+		assert.Equal(t, 2, paginator.TotalPages)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+}
+
+func TestFetchTaskById(t *testing.T) {
+	now := helper.NewTimestampFromTime(time.Now())
+	taskId := uuid.FromStringOrNil("907eefd8-181b-457b-8ca2-692c442b2b0b")
+	task := &models.Task{
+		Id:          &taskId,
+		TaskName:    "แก๊งหัวขโมยขนม",
+		Status:      "draft",
+		CreatorName: "pheethy",
+		CreatedAt:   &now,
+		UpdatedAt:   &now,
+	}
+	columns := []string{
+		"id", "task_name", "status", "creator_name", "created_at", "updated_at", "deleted_at",
+	}
+	sql := `
+	SELECT
+		(.+)
+	FROM
+		todo
+	WHERE
+		(.+)
+	`
+
+	t.Run("success", func(t *testing.T) {
+		db, sqlMock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		sqlxDB := sqlx.NewDb(db, "sqlmock")
+		defer db.Close()
+
+		rows := sqlmock.NewRows(columns).AddRow(
+			task.Id.String(), task.TaskName, task.Status, task.CreatorName, task.CreatedAt, task.UpdatedAt, nil,
+		)
+		sqlMock.ExpectQuery(sql).WillReturnRows(rows)
+
+		repo := NewTodoRepository(sqlxDB)
+		epTask, err := repo.FetchTaskById(context.Background(), &taskId)
+
+		assert.NoError(t, err)
+		assert.Equal(t, task.Id.String(), epTask.Id.String())
+		assert.Equal(t, task.TaskName, epTask.TaskName)
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		db, sqlMock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		sqlxDB := sqlx.NewDb(db, "sqlmock")
+		defer db.Close()
+
+		sqlMock.ExpectQuery(sql).WillReturnRows(sqlmock.NewRows(columns))
+
+		repo := NewTodoRepository(sqlxDB)
+		epTask, err := repo.FetchTaskById(context.Background(), &taskId)
+
+		assert.EqualError(t, err, constants.ERROR_TASK_NOT_FOUND)
+		assert.Nil(t, epTask)
+	})
+}
+
+func TestDeleteTask(t *testing.T) {
+	now := helper.NewTimestampFromTime(time.Now())
+	taskId := uuid.FromStringOrNil("907eefd8-181b-457b-8ca2-692c442b2b0b")
+	sql := `UPDATE todo SET deleted_at = (.+) WHERE (.+) deleted_at IS NULL`
+
+	t.Run("success", func(t *testing.T) {
+		db, sqlMock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		sqlxDB := sqlx.NewDb(db, "sqlmock")
+		defer db.Close()
+
+		sqlMock.ExpectExec(sql).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		repo := NewTodoRepository(sqlxDB)
+		err = repo.DeleteTask(context.Background(), &taskId, &now)
+
+		assert.NoError(t, err)
+		assert.NoError(t, sqlMock.ExpectationsWereMet())
+	})
+
+	t.Run("not_found", func(t *testing.T) {
+		db, sqlMock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		sqlxDB := sqlx.NewDb(db, "sqlmock")
+		defer db.Close()
+
+		sqlMock.ExpectExec(sql).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		repo := NewTodoRepository(sqlxDB)
+		err = repo.DeleteTask(context.Background(), &taskId, &now)
+
+		assert.EqualError(t, err, constants.ERROR_TASK_NOT_FOUND)
+	})
+}
+
+func TestSearchTask(t *testing.T) {
+	now := helper.NewTimestampFromTime(time.Now())
+	taskId := uuid.FromStringOrNil("907eefd8-181b-457b-8ca2-692c442b2b0b")
+
+	t.Run("success", func(t *testing.T) {
+		db, sqlMock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		sqlxDB := sqlx.NewDb(db, "sqlmock")
+		defer db.Close()
+
+		rows := sqlmock.NewRows([]string{
+			"search.id", "search.rank",
+			"todo.id", "todo.task_name", "todo.status", "todo.creator_name", "todo.created_at", "todo.deleted_at", "todo.updated_at",
+		}).AddRow(
+			taskId.String(), float32(0.75),
+			taskId.String(), "แก๊งหัวขโมยขนม", "draft", "pheethy", now.ToTime(), nil, now.ToTime(),
+		)
+		sqlMock.ExpectQuery(`SELECT (.+) FROM todo, plainto_tsquery(.+)`).WillReturnRows(rows)
+
+		repo := NewTodoRepository(sqlxDB)
+		results, err := repo.SearchTask(context.Background(), "ขโมย", "", 10)
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 1)
+		assert.InDelta(t, 0.75, results[0].Rank, 0.0001)
+		assert.NotNil(t, results[0].Task)
+		assert.Equal(t, "แก๊งหัวขโมยขนม", results[0].Task.TaskName)
 	})
 }
@@ -3,9 +3,28 @@ package todo
 import (
 	"context"
 	"github/pheethy/todo/models"
+	"io"
+	"mime/multipart"
+	"time"
+
+	"github.com/gofrs/uuid"
 )
 
 type TodoUsecase interface {
 	CreateTask(ctx context.Context, task *models.Task) error
-	FetchListTodo(ctx context.Context) ([]*models.Task, error)
+	ImportTasks(ctx context.Context, tasks []*models.Task) error
+	FetchListTodo(ctx context.Context, filter *models.TaskFilter, paginator *models.Paginator) ([]*models.Task, error)
+	FetchListTodoByCursor(ctx context.Context, filter *models.TaskFilter, paginator *models.CursorPaginator) ([]*models.Task, error)
+	SearchTask(ctx context.Context, q string, limit int) ([]*models.TaskSearchResult, error)
+	FetchTaskById(ctx context.Context, id *uuid.UUID) (*models.Task, error)
+	UpdateTask(ctx context.Context, id *uuid.UUID, req *models.TaskUpdate) (*models.Task, error)
+	DeleteTask(ctx context.Context, id *uuid.UUID) error
+	FetchListTrash(ctx context.Context) ([]*models.Task, error)
+	RestoreTask(ctx context.Context, id *uuid.UUID) error
+	PurgeTrash(ctx context.Context, retention time.Duration) (int64, error)
+	TransitionTask(ctx context.Context, id *uuid.UUID, toStatus models.TaskStatus, movedBy string) (*models.TaskTransition, error)
+	UploadAttachment(ctx context.Context, taskId *uuid.UUID, file *multipart.FileHeader, uploadedBy string) (*models.TaskAttachment, error)
+	FetchListAttachment(ctx context.Context, taskId *uuid.UUID) ([]*models.TaskAttachment, error)
+	OpenAttachment(ctx context.Context, taskId *uuid.UUID, id *uuid.UUID) (*models.TaskAttachment, io.ReadCloser, error)
+	DeleteAttachment(ctx context.Context, taskId *uuid.UUID, id *uuid.UUID) error
 }
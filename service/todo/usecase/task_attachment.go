@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/helper"
+	"github/pheethy/todo/models"
+	"github/pheethy/todo/storage"
+	"io"
+	"log"
+	"mime/multipart"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+/*
+UploadAttachment แนบไฟล์กับ task ที่ผู้ใช้มีสิทธิ์ จำกัดขนาดตาม APP_FILE_LIMIT
+checksum คือ sha256 ของเนื้อไฟล์ คำนวณระหว่างอัปโหลดโดยไม่ต้องอ่านไฟล์ซ้ำ
+*/
+func (u todoUsecase) UploadAttachment(ctx context.Context, taskId *uuid.UUID, file *multipart.FileHeader, uploadedBy string) (*models.TaskAttachment, error) {
+	if file == nil {
+		return nil, errors.New(constants.ERROR_FILE_REQUIRED)
+	}
+	if limit := u.cfg.FileLimit(); limit > 0 && file.Size > int64(limit) {
+		return nil, errors.New(constants.ERROR_FILE_TOO_LARGE)
+	}
+	if _, err := u.FetchTaskById(ctx, taskId); err != nil {
+		return nil, err
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	contentType, err := storage.SniffContentType(src)
+	if err != nil {
+		return nil, err
+	}
+
+	var attachment = &models.TaskAttachment{
+		TaskId:      taskId,
+		Filename:    filepath.Base(file.Filename),
+		ContentType: contentType,
+		UploadedBy:  uploadedBy,
+	}
+	attachment.NewId()
+	attachment.StorageKey = attachmentKey(attachment)
+	attachment.SetCreatedAt(helper.NewTimestampFromTime(time.Now()))
+
+	var hash = sha256.New()
+	var counter = &byteCounter{}
+	if _, err := u.storage.Upload(ctx, attachment.StorageKey, io.TeeReader(src, io.MultiWriter(hash, counter)), contentType); err != nil {
+		return nil, err
+	}
+	attachment.Size = counter.n
+	attachment.Checksum = hex.EncodeToString(hash.Sum(nil))
+
+	if err := u.todoRepo.CreateAttachment(ctx, attachment); err != nil {
+		u.deleteAttachmentFile(ctx, attachment.StorageKey)
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+func (u todoUsecase) FetchListAttachment(ctx context.Context, taskId *uuid.UUID) ([]*models.TaskAttachment, error) {
+	if _, err := u.FetchTaskById(ctx, taskId); err != nil {
+		return nil, err
+	}
+	return u.todoRepo.FetchListAttachment(ctx, taskId)
+}
+
+/* OpenAttachment คืนข้อมูลไฟล์แนบพร้อม reader ของเนื้อไฟล์ ผู้เรียกต้อง Close reader เอง */
+func (u todoUsecase) OpenAttachment(ctx context.Context, taskId *uuid.UUID, id *uuid.UUID) (*models.TaskAttachment, io.ReadCloser, error) {
+	if _, err := u.FetchTaskById(ctx, taskId); err != nil {
+		return nil, nil, err
+	}
+	attachment, err := u.todoRepo.FetchAttachmentById(ctx, taskId, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	file, err := u.storage.Open(ctx, attachment.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return attachment, file, nil
+}
+
+func (u todoUsecase) DeleteAttachment(ctx context.Context, taskId *uuid.UUID, id *uuid.UUID) error {
+	if _, err := u.FetchTaskById(ctx, taskId); err != nil {
+		return err
+	}
+	attachment, err := u.todoRepo.FetchAttachmentById(ctx, taskId, id)
+	if err != nil {
+		return err
+	}
+	if err := u.todoRepo.DeleteAttachment(ctx, attachment.Id); err != nil {
+		return err
+	}
+	u.deleteAttachmentFile(ctx, attachment.StorageKey)
+	return nil
+}
+
+/* deleteAttachmentFile ลบไฟล์ไม่สำเร็จจะแค่ log ไว้ เพราะข้อมูลใน database ถูกลบไปแล้ว */
+func (u todoUsecase) deleteAttachmentFile(ctx context.Context, key string) {
+	if err := u.storage.Delete(ctx, key); err != nil {
+		log.Printf("delete attachment file %s failed: %v", key, err)
+	}
+}
+
+/* attachmentKey path ของไฟล์แนบใน storage: tasks/<task_id>/<attachment_id> ไม่ใช้ชื่อไฟล์จาก client */
+func attachmentKey(attachment *models.TaskAttachment) string {
+	return path.Join("tasks", attachment.TaskId.String(), attachment.Id.String())
+}
+
+/* byteCounter นับจำนวน byte ที่เขียนผ่าน เพื่อเก็บขนาดไฟล์จริงแทนค่าที่ client ส่งมา */
+type byteCounter struct {
+	n int64
+}
+
+func (c *byteCounter) Write(p []byte) (int, error) {
+	c.n += int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github/pheethy/todo/config"
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/models"
+	"github/pheethy/todo/service/todo/mocks"
+	"github/pheethy/todo/storage"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type appConfig struct {
+	config.IAppConfig
+	fileLimit int
+}
+
+func (c appConfig) FileLimit() int {
+	return c.fileLimit
+}
+
+func newFileHeader(t *testing.T, filename string, content []byte) *multipart.FileHeader {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", filename)
+	assert.NoError(t, err)
+	_, err = part.Write(content)
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(1 << 20)
+	assert.NoError(t, err)
+	t.Cleanup(func() { form.RemoveAll() })
+
+	return form.File["file"][0]
+}
+
+func TestUploadAttachment(t *testing.T) {
+	taskId := uuid.FromStringOrNil("907eefd8-181b-457b-8ca2-692c442b2b0b")
+	task := &models.Task{Id: &taskId, TaskName: "แก๊งหัวขโมยขนม"}
+	content := []byte("hello attachment")
+	sum := sha256.Sum256(content)
+
+	t.Run("success", func(t *testing.T) {
+		dir := t.TempDir()
+		repo := mocks.NewTodoRepository(t)
+		repo.On("FetchTaskById", mock.Anything, &taskId).Return(task, nil)
+		repo.On("CreateAttachment", mock.Anything, mock.AnythingOfType("*models.TaskAttachment")).Return(nil)
+
+		us := NewTodoUsecase(appConfig{fileLimit: 1024}, repo, storage.NewLocalStorage(dir, ""))
+		attachment, err := us.UploadAttachment(adminCtx, &taskId, newFileHeader(t, "../note.txt", content), "pheethy")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "note.txt", attachment.Filename)
+		assert.Equal(t, int64(len(content)), attachment.Size)
+		assert.Equal(t, hex.EncodeToString(sum[:]), attachment.Checksum)
+		assert.Equal(t, "text/plain; charset=utf-8", attachment.ContentType)
+
+		stored, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(attachment.StorageKey)))
+		assert.NoError(t, err)
+		assert.Equal(t, content, stored)
+	})
+
+	t.Run("too_large", func(t *testing.T) {
+		repo := mocks.NewTodoRepository(t)
+
+		us := NewTodoUsecase(appConfig{fileLimit: 4}, repo, storage.NewLocalStorage(t.TempDir(), ""))
+		attachment, err := us.UploadAttachment(adminCtx, &taskId, newFileHeader(t, "note.txt", content), "pheethy")
+
+		assert.Nil(t, attachment)
+		assert.EqualError(t, err, constants.ERROR_FILE_TOO_LARGE)
+	})
+
+	t.Run("remove_file_when_insert_failed", func(t *testing.T) {
+		dir := t.TempDir()
+		repo := mocks.NewTodoRepository(t)
+		repo.On("FetchTaskById", mock.Anything, &taskId).Return(task, nil)
+		repo.On("CreateAttachment", mock.Anything, mock.AnythingOfType("*models.TaskAttachment")).Return(errors.New("insert failed"))
+
+		us := NewTodoUsecase(appConfig{fileLimit: 1024}, repo, storage.NewLocalStorage(dir, ""))
+		_, err := us.UploadAttachment(adminCtx, &taskId, newFileHeader(t, "note.txt", content), "pheethy")
+
+		assert.EqualError(t, err, "insert failed")
+		files, err := os.ReadDir(filepath.Join(dir, "tasks", taskId.String()))
+		assert.NoError(t, err)
+		assert.Empty(t, files)
+	})
+}
+
+func TestPurgeTrashRemovesAttachmentFiles(t *testing.T) {
+	dir := t.TempDir()
+	fileStorage := storage.NewLocalStorage(dir, "")
+	_, err := fileStorage.Upload(context.Background(), "tasks/1/a", bytes.NewReader([]byte("a")), "text/plain")
+	assert.NoError(t, err)
+
+	repo := mocks.NewTodoRepository(t)
+	repo.On("PurgeTrash", mock.Anything, mock.Anything).Return(int64(1), []string{"tasks/1/a"}, nil)
+
+	purged, err := NewTodoUsecase(nil, repo, fileStorage).PurgeTrash(context.Background(), 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+	_, err = fileStorage.Open(context.Background(), "tasks/1/a")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github/pheethy/todo/auth"
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/models"
+)
+
+/*
+authorizeTask ลูกค้าจัดการได้เฉพาะ task ที่ตัวเองสร้าง admin จัดการได้ทุก task
+context ที่ไม่มีผู้ใช้ถูกปฏิเสธเสมอ งานเบื้องหลังต้องใช้ auth.WithSystemUser
+*/
+func authorizeTask(ctx context.Context, task *models.Task) error {
+	user := auth.UserFromContext(ctx)
+	if user == nil {
+		return errors.New(constants.ERROR_PERMISSION_DENIED)
+	}
+	if auth.IsAdmin(user) {
+		return nil
+	}
+	if task.CreatorName != user.Username {
+		return errors.New(constants.ERROR_PERMISSION_DENIED)
+	}
+	return nil
+}
+
+/* ownerScope คืนชื่อผู้สร้างที่ลูกค้าถูกจำกัดให้เห็น, "" สำหรับ admin และ error เมื่อไม่มีผู้ใช้ */
+func ownerScope(ctx context.Context) (string, error) {
+	user := auth.UserFromContext(ctx)
+	if user == nil {
+		return "", errors.New(constants.ERROR_PERMISSION_DENIED)
+	}
+	if auth.IsAdmin(user) {
+		return "", nil
+	}
+	return user.Username, nil
+}
@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+/* highlightTerms ครอบคำที่ค้นเจอใน text ด้วย <mark></mark> โดย escape html ของ text ก่อน */
+func highlightTerms(text string, q string) string {
+	var escaped = html.EscapeString(text)
+	var terms = make([]string, 0)
+	for _, term := range strings.Fields(q) {
+		terms = append(terms, regexp.QuoteMeta(html.EscapeString(term)))
+	}
+	if len(terms) == 0 {
+		return escaped
+	}
+
+	exp := regexp.MustCompile("(?i)" + strings.Join(terms, "|"))
+	return exp.ReplaceAllString(escaped, "<mark>$0</mark>")
+}
@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/models"
+	"github/pheethy/todo/service/todo"
+)
+
+/* taskTransitions คือ status ถัดไปที่ task แต่ละ status สามารถย้ายไปได้ (ย้ายได้ทีละขั้นเท่านั้น) */
+var taskTransitions = map[models.TaskStatus][]models.TaskStatus{
+	constants.TASK_STATUS_DRAFT:       {constants.TASK_STATUS_IN_PROGRESS},
+	constants.TASK_STATUS_IN_PROGRESS: {constants.TASK_STATUS_DRAFT, constants.TASK_STATUS_DONE},
+	constants.TASK_STATUS_DONE:        {constants.TASK_STATUS_IN_PROGRESS},
+}
+
+func validateTransition(from models.TaskStatus, to models.TaskStatus) error {
+	for _, next := range taskTransitions[from] {
+		if next == to {
+			return nil
+		}
+	}
+	return todo.ErrIllegalTransition{From: from, To: to}
+}
@@ -2,22 +2,217 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"github/pheethy/todo/config"
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/helper"
 	"github/pheethy/todo/models"
 	"github/pheethy/todo/service/todo"
+	"github/pheethy/todo/storage"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
 )
 
 type todoUsecase struct {
+	cfg      config.IAppConfig
 	todoRepo todo.TodoRepository
+	storage  storage.Storage
 }
 
-func NewTodoUsecase(todoRepo todo.TodoRepository) todo.TodoUsecase {
-	return todoUsecase{todoRepo: todoRepo}
+func NewTodoUsecase(cfg config.IAppConfig, todoRepo todo.TodoRepository, storage storage.Storage) todo.TodoUsecase {
+	return todoUsecase{cfg: cfg, todoRepo: todoRepo, storage: storage}
 }
 
 func (u todoUsecase) CreateTask(ctx context.Context, task *models.Task) error {
 	return u.todoRepo.CreateTask(ctx, task)
 }
 
-func (u todoUsecase) FetchListTodo(ctx context.Context) ([]*models.Task, error) {
-	return u.todoRepo.FetchListTodo(ctx)
+/* ImportTasks สร้าง task หลายรายการใน transaction เดียว */
+func (u todoUsecase) ImportTasks(ctx context.Context, tasks []*models.Task) error {
+	if len(tasks) == 0 {
+		return errors.New(constants.ERROR_TASK_IMPORT_EMPTY)
+	}
+	return u.todoRepo.CreateTasks(ctx, tasks)
+}
+
+func (u todoUsecase) FetchListTodo(ctx context.Context, filter *models.TaskFilter, paginator *models.Paginator) ([]*models.Task, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	owner, err := ownerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if owner != "" {
+		filter.CreatorName = owner
+	}
+	return u.todoRepo.FetchListTodo(ctx, filter, paginator)
+}
+
+func (u todoUsecase) FetchListTodoByCursor(ctx context.Context, filter *models.TaskFilter, paginator *models.CursorPaginator) ([]*models.Task, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	owner, err := ownerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if owner != "" {
+		filter.CreatorName = owner
+	}
+
+	var cursor = paginator.Cursor
+	tasks, err := u.todoRepo.FetchListTodoByCursor(ctx, filter, cursor, paginator.PerPage)
+	if err != nil {
+		return nil, err
+	}
+
+	var hasMore = len(tasks) > paginator.PerPage
+	if hasMore {
+		tasks = tasks[:paginator.PerPage]
+	}
+	var isBackward = cursor != nil && cursor.Prev
+	if isBackward {
+		/* หน้าก่อนหน้าถูก query แบบ ASC ต้องกลับลำดับให้เหมือนหน้าปกติ */
+		for i, j := 0, len(tasks)-1; i < j; i, j = i+1, j-1 {
+			tasks[i], tasks[j] = tasks[j], tasks[i]
+		}
+	}
+	if len(tasks) == 0 {
+		return tasks, nil
+	}
+
+	first, last := tasks[0], tasks[len(tasks)-1]
+	if hasMore || isBackward {
+		paginator.NextCursor = models.NewTaskCursor(last, false).Encode()
+	}
+	if (cursor != nil && !isBackward) || (isBackward && hasMore) {
+		paginator.PrevCursor = models.NewTaskCursor(first, true).Encode()
+	}
+
+	return tasks, nil
+}
+
+func (u todoUsecase) SearchTask(ctx context.Context, q string, limit int) ([]*models.TaskSearchResult, error) {
+	q = strings.TrimSpace(q)
+	if q == "" {
+		return nil, errors.New(constants.ERROR_TASK_SEARCH_QUERY_REQUIRED)
+	}
+	if limit < 1 {
+		limit = models.DEFAULT_PER_PAGE
+	}
+	if limit > models.MAX_PER_PAGE {
+		limit = models.MAX_PER_PAGE
+	}
+
+	owner, err := ownerScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	results, err := u.todoRepo.SearchTask(ctx, q, owner, limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		if result.Task != nil {
+			result.Highlight = highlightTerms(result.Task.TaskName, q)
+		}
+	}
+
+	return results, nil
+}
+
+func (u todoUsecase) FetchTaskById(ctx context.Context, id *uuid.UUID) (*models.Task, error) {
+	task, err := u.todoRepo.FetchTaskById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeTask(ctx, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+/* UpdateTask แก้ไขเฉพาะ field ที่ส่งมา ถ้าไม่มี field ให้แก้ไขจะคืน task เดิมโดยไม่เขียน database */
+func (u todoUsecase) UpdateTask(ctx context.Context, id *uuid.UUID, req *models.TaskUpdate) (*models.Task, error) {
+	task, err := u.FetchTaskById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.TaskName == nil {
+		return task, nil
+	}
+
+	task.TaskName = strings.TrimSpace(*req.TaskName)
+	if task.TaskName == "" {
+		return nil, errors.New(constants.ERROR_TASK_NAME_REQUIRED)
+	}
+	task.SetUpatedAt(helper.NewTimestampFromTime(time.Now()))
+
+	if err := u.todoRepo.UpdateTask(ctx, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (u todoUsecase) DeleteTask(ctx context.Context, id *uuid.UUID) error {
+	if _, err := u.FetchTaskById(ctx, id); err != nil {
+		return err
+	}
+	var now = helper.NewTimestampFromTime(time.Now())
+	return u.todoRepo.DeleteTask(ctx, id, &now)
+}
+
+func (u todoUsecase) FetchListTrash(ctx context.Context) ([]*models.Task, error) {
+	return u.todoRepo.FetchListTrash(ctx)
+}
+
+func (u todoUsecase) RestoreTask(ctx context.Context, id *uuid.UUID) error {
+	var now = helper.NewTimestampFromTime(time.Now())
+	return u.todoRepo.RestoreTask(ctx, id, &now)
+}
+
+/* PurgeTrash ลบ task ที่อยู่ในถังขยะนานกว่า retention ออกจาก database ถาวร แล้วลบไฟล์แนบของ task เหล่านั้นออกจาก storage */
+func (u todoUsecase) PurgeTrash(ctx context.Context, retention time.Duration) (int64, error) {
+	var before = helper.NewTimestampFromTime(time.Now().Add(-retention))
+	purged, storageKeys, err := u.todoRepo.PurgeTrash(ctx, &before)
+	if err != nil {
+		return 0, err
+	}
+	for _, key := range storageKeys {
+		u.deleteAttachmentFile(ctx, key)
+	}
+	return purged, nil
+}
+
+func (u todoUsecase) TransitionTask(ctx context.Context, id *uuid.UUID, toStatus models.TaskStatus, movedBy string) (*models.TaskTransition, error) {
+	task, err := u.FetchTaskById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateTransition(task.Status, toStatus); err != nil {
+		return nil, err
+	}
+
+	var now = helper.NewTimestampFromTime(time.Now())
+	var transition = &models.TaskTransition{
+		TaskId:     task.Id,
+		FromStatus: task.Status,
+		ToStatus:   toStatus,
+		MovedBy:    movedBy,
+	}
+	transition.NewId()
+	transition.SetMovedAt(now)
+
+	task.Status = toStatus
+	task.SetUpatedAt(now)
+
+	if err := u.todoRepo.TransitionTask(ctx, task, transition); err != nil {
+		return nil, err
+	}
+
+	return transition, nil
 }
@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"context"
+	"github/pheethy/todo/auth"
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/helper"
+	"github/pheethy/todo/models"
+	"github/pheethy/todo/service/todo"
+	"github/pheethy/todo/service/todo/mocks"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+/* adminCtx context ของ admin สำหรับเทสที่ไม่ได้ทดสอบสิทธิ์ */
+var adminCtx = auth.WithUser(context.Background(), &models.UserClaims{Username: "admin001", RoleId: constants.ROLE_ADMIN})
+
+func TestTransitionTask(t *testing.T) {
+	taskId := uuid.FromStringOrNil("907eefd8-181b-457b-8ca2-692c442b2b0b")
+
+	t.Run("success", func(t *testing.T) {
+		task := &models.Task{Id: &taskId, TaskName: "แก๊งหัวขโมยขนม", Status: constants.TASK_STATUS_DRAFT}
+		repo := mocks.NewTodoRepository(t)
+		repo.On("FetchTaskById", mock.Anything, &taskId).Return(task, nil)
+		repo.On("TransitionTask", mock.Anything, task, mock.AnythingOfType("*models.TaskTransition")).Return(nil)
+
+		us := NewTodoUsecase(nil, repo, nil)
+		transition, err := us.TransitionTask(adminCtx, &taskId, constants.TASK_STATUS_IN_PROGRESS, "pheethy")
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.TaskStatus(constants.TASK_STATUS_DRAFT), transition.FromStatus)
+		assert.Equal(t, models.TaskStatus(constants.TASK_STATUS_IN_PROGRESS), transition.ToStatus)
+		assert.Equal(t, "pheethy", transition.MovedBy)
+		assert.NotNil(t, transition.MovedAt)
+		assert.Equal(t, models.TaskStatus(constants.TASK_STATUS_IN_PROGRESS), task.Status)
+	})
+
+	t.Run("illegal_transition", func(t *testing.T) {
+		cases := map[models.TaskStatus]models.TaskStatus{
+			constants.TASK_STATUS_DONE:  constants.TASK_STATUS_DRAFT,
+			constants.TASK_STATUS_DRAFT: constants.TASK_STATUS_DONE,
+		}
+		for from, to := range cases {
+			task := &models.Task{Id: &taskId, Status: from}
+			repo := mocks.NewTodoRepository(t)
+			repo.On("FetchTaskById", mock.Anything, &taskId).Return(task, nil)
+
+			us := NewTodoUsecase(nil, repo, nil)
+			transition, err := us.TransitionTask(adminCtx, &taskId, to, "pheethy")
+
+			assert.Nil(t, transition)
+			assert.ErrorAs(t, err, &todo.ErrIllegalTransition{})
+		}
+	})
+}
+
+func TestUpdateTask(t *testing.T) {
+	taskId := uuid.FromStringOrNil("907eefd8-181b-457b-8ca2-692c442b2b0b")
+	var name = func(s string) *string {
+		return &s
+	}
+
+	t.Run("update_task_name", func(t *testing.T) {
+		task := &models.Task{Id: &taskId, TaskName: "แก๊งหัวขโมยขนม"}
+		repo := mocks.NewTodoRepository(t)
+		repo.On("FetchTaskById", mock.Anything, &taskId).Return(task, nil)
+		repo.On("UpdateTask", mock.Anything, task).Return(nil)
+
+		epTask, err := NewTodoUsecase(nil, repo, nil).UpdateTask(adminCtx, &taskId, &models.TaskUpdate{TaskName: name(" ขนมหาย ")})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ขนมหาย", epTask.TaskName)
+		assert.NotNil(t, epTask.UpdatedAt)
+	})
+
+	t.Run("no_field_sent", func(t *testing.T) {
+		task := &models.Task{Id: &taskId, TaskName: "แก๊งหัวขโมยขนม"}
+		repo := mocks.NewTodoRepository(t)
+		repo.On("FetchTaskById", mock.Anything, &taskId).Return(task, nil)
+
+		epTask, err := NewTodoUsecase(nil, repo, nil).UpdateTask(adminCtx, &taskId, &models.TaskUpdate{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "แก๊งหัวขโมยขนม", epTask.TaskName)
+		repo.AssertNotCalled(t, "UpdateTask", mock.Anything, mock.Anything)
+	})
+
+	t.Run("empty_task_name", func(t *testing.T) {
+		task := &models.Task{Id: &taskId, TaskName: "แก๊งหัวขโมยขนม"}
+		repo := mocks.NewTodoRepository(t)
+		repo.On("FetchTaskById", mock.Anything, &taskId).Return(task, nil)
+
+		epTask, err := NewTodoUsecase(nil, repo, nil).UpdateTask(adminCtx, &taskId, &models.TaskUpdate{TaskName: name("  ")})
+
+		assert.EqualError(t, err, constants.ERROR_TASK_NAME_REQUIRED)
+		assert.Nil(t, epTask)
+	})
+}
+
+func TestFetchListTodoByCursor(t *testing.T) {
+	var newTasks = func(n int) []*models.Task {
+		tasks := make([]*models.Task, 0, n)
+		for i := 0; i < n; i++ {
+			task := &models.Task{TaskName: "แก๊งหัวขโมยขนม"}
+			task.NewId()
+			task.SetCreatedAt(helper.NewTimestampFromTime(time.Now().Add(-time.Duration(i) * time.Minute)))
+			tasks = append(tasks, task)
+		}
+		return tasks
+	}
+
+	t.Run("first_page", func(t *testing.T) {
+		tasks := newTasks(3)
+		repo := mocks.NewTodoRepository(t)
+		repo.On("FetchListTodoByCursor", mock.Anything, mock.Anything, (*models.TaskCursor)(nil), 2).Return(tasks, nil)
+
+		paginator := models.NewCursorPaginator(nil, 2)
+		epTasks, err := NewTodoUsecase(nil, repo, nil).FetchListTodoByCursor(adminCtx, models.NewTaskFilter(), paginator)
+
+		assert.NoError(t, err)
+		assert.Len(t, epTasks, 2)
+		assert.Empty(t, paginator.PrevCursor)
+
+		next, err := models.DecodeTaskCursor(paginator.NextCursor)
+		assert.NoError(t, err)
+		assert.Equal(t, *tasks[1].Id, next.Id)
+		assert.False(t, next.Prev)
+	})
+
+	t.Run("prev_page", func(t *testing.T) {
+		tasks := newTasks(3)
+		cursor := models.NewTaskCursor(tasks[2], true)
+		/* repository ส่งกลับแบบ ASC */
+		repo := mocks.NewTodoRepository(t)
+		repo.On("FetchListTodoByCursor", mock.Anything, mock.Anything, cursor, 2).Return([]*models.Task{tasks[1], tasks[0]}, nil)
+
+		paginator := models.NewCursorPaginator(cursor, 2)
+		epTasks, err := NewTodoUsecase(nil, repo, nil).FetchListTodoByCursor(adminCtx, models.NewTaskFilter(), paginator)
+
+		assert.NoError(t, err)
+		assert.Equal(t, tasks[0].Id, epTasks[0].Id)
+		assert.Empty(t, paginator.PrevCursor)
+		assert.NotEmpty(t, paginator.NextCursor)
+	})
+}
+
+func TestHighlightTerms(t *testing.T) {
+	assert.Equal(t, "แก๊งหัว<mark>ขโมย</mark>ขนม", highlightTerms("แก๊งหัวขโมยขนม", "ขโมย"))
+	assert.Equal(t, "<mark>Fix</mark> &lt;b&gt; <mark>bug</mark>", highlightTerms("Fix <b> bug", "fix BUG"))
+}
+
+func TestFetchTaskByIdOwnership(t *testing.T) {
+	taskId := uuid.FromStringOrNil("907eefd8-181b-457b-8ca2-692c442b2b0b")
+	task := &models.Task{Id: &taskId, TaskName: "แก๊งหัวขโมยขนม", CreatorName: "customer001"}
+
+	cases := map[string]struct {
+		user *models.UserClaims
+		err  bool
+	}{
+		"owner":       {user: &models.UserClaims{Username: "customer001", RoleId: constants.ROLE_CUSTOMER}},
+		"other_owner": {user: &models.UserClaims{Username: "customer002", RoleId: constants.ROLE_CUSTOMER}, err: true},
+		"admin":       {user: &models.UserClaims{Username: "admin001", RoleId: constants.ROLE_ADMIN}},
+		"no_user":     {err: true},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			repo := mocks.NewTodoRepository(t)
+			repo.On("FetchTaskById", mock.Anything, &taskId).Return(task, nil)
+
+			ctx := auth.WithUser(context.Background(), tc.user)
+			epTask, err := NewTodoUsecase(nil, repo, nil).FetchTaskById(ctx, &taskId)
+
+			if tc.err {
+				assert.EqualError(t, err, constants.ERROR_PERMISSION_DENIED)
+				assert.Nil(t, epTask)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, task, epTask)
+		})
+	}
+}
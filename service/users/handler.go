@@ -0,0 +1,12 @@
+package users
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+type UsersHandler interface {
+	SignUp(c *gin.Context)
+	SignIn(c *gin.Context)
+	RefreshToken(c *gin.Context)
+	SignOut(c *gin.Context)
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"fmt"
+	"github/pheethy/todo/auth"
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/models"
+	"github/pheethy/todo/service/users"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type usersHandler struct {
+	usersUs users.UsersUsecase
+}
+
+func NewUsersHandler(usersUs users.UsersUsecase) users.UsersHandler {
+	return usersHandler{usersUs: usersUs}
+}
+
+func (h usersHandler) SignUp(c *gin.Context) {
+	var ctx = c.Request.Context()
+	var req = new(models.UserSignUp)
+
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("can't binding data: %v", err))
+		return
+	}
+
+	user, err := h.usersUs.SignUp(ctx, req)
+	if err != nil {
+		c.JSON(h.statusFromError(err), err.Error())
+		return
+	}
+
+	resp := map[string]interface{}{
+		"message": "Created.",
+		"user":    user,
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (h usersHandler) SignIn(c *gin.Context) {
+	var ctx = c.Request.Context()
+	var req = new(models.UserSignIn)
+
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("can't binding data: %v", err))
+		return
+	}
+
+	passport, err := h.usersUs.SignIn(ctx, req)
+	if err != nil {
+		c.JSON(h.statusFromError(err), err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, passport)
+}
+
+func (h usersHandler) RefreshToken(c *gin.Context) {
+	var ctx = c.Request.Context()
+	var req = struct {
+		RefreshToken string `json:"refresh_token" binding:"required"`
+	}{}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, fmt.Sprintf("can't binding data: %v", err))
+		return
+	}
+
+	passport, err := h.usersUs.RefreshToken(ctx, req.RefreshToken)
+	if err != nil {
+		c.JSON(h.statusFromError(err), err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, passport)
+}
+
+func (h usersHandler) SignOut(c *gin.Context) {
+	var ctx = c.Request.Context()
+
+	user := auth.UserFromContext(ctx)
+	if user == nil {
+		c.JSON(http.StatusUnauthorized, constants.ERROR_TOKEN_MISSING)
+		return
+	}
+	accessToken := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+
+	if err := h.usersUs.SignOut(ctx, user.Id, accessToken); err != nil {
+		c.JSON(h.statusFromError(err), err.Error())
+		return
+	}
+
+	resp := map[string]interface{}{
+		"message": "Signed out.",
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h usersHandler) statusFromError(err error) int {
+	switch err.Error() {
+	case constants.ERROR_USERNAME_WAS_DUPLICATE_SERVICE, constants.ERROR_EMAIL_WAS_DUPLICATE_SERVICE:
+		return http.StatusConflict
+	case constants.ERROR_USER_CREDENTIAL_INVALID, constants.ERROR_TOKEN_INVALID, constants.ERROR_TOKEN_EXPIRED, constants.ERROR_TOKEN_REVOKED, constants.ERROR_OAUTH_NOT_FOUND:
+		return http.StatusUnauthorized
+	case constants.ERROR_USER_NOT_FOUND:
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
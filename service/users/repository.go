@@ -0,0 +1,17 @@
+package users
+
+import (
+	"context"
+	"github/pheethy/todo/models"
+)
+
+type UsersRepository interface {
+	CreateUser(ctx context.Context, user *models.User) error
+	FetchUserById(ctx context.Context, id string) (*models.User, error)
+	FetchUserByUsername(ctx context.Context, username string) (*models.User, error)
+	CreateOauth(ctx context.Context, oauth *models.Oauth) error
+	FetchOauthByAccessToken(ctx context.Context, accessToken string) (*models.Oauth, error)
+	FetchOauthByRefreshToken(ctx context.Context, refreshToken string) (*models.Oauth, error)
+	UpdateOauth(ctx context.Context, oauth *models.Oauth) error
+	DeleteOauth(ctx context.Context, userId string, accessToken string) error
+}
@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/models"
+	"github/pheethy/todo/orm"
+	"github/pheethy/todo/service/users"
+	"strings"
+
+	"github.com/BlackMocca/sqlx"
+)
+
+type usersRepository struct {
+	db *sqlx.DB
+}
+
+func NewUsersRepository(db *sqlx.DB) users.UsersRepository {
+	return usersRepository{db: db}
+}
+
+func (r usersRepository) CreateUser(ctx context.Context, user *models.User) error {
+	sql := `
+		INSERT INTO users (
+			username,
+			email,
+			password,
+			role_id,
+			created_at,
+			updated_at
+		)
+		VALUES(
+			$1::text,
+			$2::text,
+			$3::text,
+			$4::int,
+			$5::timestamp,
+			$6::timestamp
+		)
+		RETURNING id
+	`
+	if err := r.db.QueryRowxContext(ctx, sql,
+		user.Username,
+		user.Email,
+		user.Password,
+		user.RoleId,
+		user.CreatedAt,
+		user.UpdatedAt,
+	).Scan(&user.Id); err != nil {
+		switch {
+		case strings.Contains(err.Error(), constants.ERROR_USERNAME_WAS_DUPLICATE):
+			return errors.New(constants.ERROR_USERNAME_WAS_DUPLICATE_SERVICE)
+		case strings.Contains(err.Error(), constants.ERROR_EMAIL_WAS_DUPLICATE):
+			return errors.New(constants.ERROR_EMAIL_WAS_DUPLICATE_SERVICE)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r usersRepository) FetchUserById(ctx context.Context, id string) (*models.User, error) {
+	return r.fetchUser(ctx, "id", id)
+}
+
+func (r usersRepository) FetchUserByUsername(ctx context.Context, username string) (*models.User, error) {
+	return r.fetchUser(ctx, "username", username)
+}
+
+func (r usersRepository) fetchUser(ctx context.Context, column string, value string) (*models.User, error) {
+	sql := `
+	SELECT
+		id,
+		username,
+		email,
+		password,
+		role_id,
+		created_at,
+		updated_at
+	FROM
+		users
+	WHERE
+		` + column + ` = $1::text
+	`
+	rows, err := r.db.QueryxContext(ctx, sql, value)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	mapper, err := orm.Orm(new(models.User), rows, orm.NewMapperOption())
+	if err != nil {
+		return nil, err
+	}
+
+	users := mapper.GetData().([]*models.User)
+	if len(users) == 0 {
+		return nil, errors.New(constants.ERROR_USER_NOT_FOUND)
+	}
+
+	return users[0], nil
+}
+
+func (r usersRepository) CreateOauth(ctx context.Context, oauth *models.Oauth) error {
+	sql := `
+		INSERT INTO oauth (
+			id,
+			user_id,
+			access_token,
+			refresh_token,
+			created_at,
+			updated_at
+		)
+		VALUES(
+			$1::uuid,
+			$2::text,
+			$3::text,
+			$4::text,
+			$5::timestamp,
+			$6::timestamp
+		)
+	`
+	_, err := r.db.ExecContext(ctx, sql,
+		oauth.Id,
+		oauth.UserId,
+		oauth.AccessToken,
+		oauth.RefreshToken,
+		oauth.CreatedAt,
+		oauth.UpdatedAt,
+	)
+	return err
+}
+
+func (r usersRepository) FetchOauthByAccessToken(ctx context.Context, accessToken string) (*models.Oauth, error) {
+	return r.fetchOauth(ctx, "access_token", accessToken)
+}
+
+func (r usersRepository) FetchOauthByRefreshToken(ctx context.Context, refreshToken string) (*models.Oauth, error) {
+	return r.fetchOauth(ctx, "refresh_token", refreshToken)
+}
+
+func (r usersRepository) fetchOauth(ctx context.Context, column string, value string) (*models.Oauth, error) {
+	sql := `
+	SELECT
+		id,
+		user_id,
+		access_token,
+		refresh_token,
+		created_at,
+		updated_at
+	FROM
+		oauth
+	WHERE
+		` + column + ` = $1::text
+	`
+	rows, err := r.db.QueryxContext(ctx, sql, value)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	mapper, err := orm.Orm(new(models.Oauth), rows, orm.NewMapperOption())
+	if err != nil {
+		return nil, err
+	}
+
+	oauths := mapper.GetData().([]*models.Oauth)
+	if len(oauths) == 0 {
+		return nil, errors.New(constants.ERROR_OAUTH_NOT_FOUND)
+	}
+
+	return oauths[0], nil
+}
+
+func (r usersRepository) UpdateOauth(ctx context.Context, oauth *models.Oauth) error {
+	sql := `
+		UPDATE oauth
+		SET
+			access_token = $2::text,
+			refresh_token = $3::text,
+			updated_at = $4::timestamp
+		WHERE
+			id = $1::uuid
+	`
+	result, err := r.db.ExecContext(ctx, sql,
+		oauth.Id,
+		oauth.AccessToken,
+		oauth.RefreshToken,
+		oauth.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		if err != nil {
+			return err
+		}
+		return errors.New(constants.ERROR_OAUTH_NOT_FOUND)
+	}
+
+	return nil
+}
+
+func (r usersRepository) DeleteOauth(ctx context.Context, userId string, accessToken string) error {
+	sql := `
+		DELETE FROM oauth
+		WHERE
+			user_id = $1::text
+		AND
+			access_token = $2::text
+	`
+	result, err := r.db.ExecContext(ctx, sql, userId, accessToken)
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+		if err != nil {
+			return err
+		}
+		return errors.New(constants.ERROR_OAUTH_NOT_FOUND)
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package users
+
+import (
+	"context"
+	"github/pheethy/todo/models"
+)
+
+type UsersUsecase interface {
+	SignUp(ctx context.Context, req *models.UserSignUp) (*models.User, error)
+	CreateAdmin(ctx context.Context, req *models.UserSignUp) (*models.User, error)
+	SignIn(ctx context.Context, req *models.UserSignIn) (*models.UserPassport, error)
+	RefreshToken(ctx context.Context, refreshToken string) (*models.UserPassport, error)
+	SignOut(ctx context.Context, userId string, accessToken string) error
+	Authenticate(ctx context.Context, accessToken string) (*models.UserClaims, error)
+}
@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github/pheethy/todo/auth"
+	"github/pheethy/todo/config"
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/helper"
+	"github/pheethy/todo/models"
+	"github/pheethy/todo/service/users"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+type usersUsecase struct {
+	cfg       config.IJwtConfig
+	usersRepo users.UsersRepository
+}
+
+func NewUsersUsecase(cfg config.IJwtConfig, usersRepo users.UsersRepository) users.UsersUsecase {
+	return usersUsecase{cfg: cfg, usersRepo: usersRepo}
+}
+
+func (u usersUsecase) SignUp(ctx context.Context, req *models.UserSignUp) (*models.User, error) {
+	return u.createUser(ctx, req, constants.ROLE_CUSTOMER)
+}
+
+/* CreateAdmin สร้างผู้ใช้ role admin ไม่มี route ให้เรียก ใช้ผ่านคำสั่ง create-admin เท่านั้น */
+func (u usersUsecase) CreateAdmin(ctx context.Context, req *models.UserSignUp) (*models.User, error) {
+	return u.createUser(ctx, req, constants.ROLE_ADMIN)
+}
+
+func (u usersUsecase) createUser(ctx context.Context, req *models.UserSignUp, roleId int) (*models.User, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	var now = helper.NewTimestampFromTime(time.Now())
+	var user = &models.User{
+		Username: strings.TrimSpace(req.Username),
+		Email:    strings.ToLower(strings.TrimSpace(req.Email)),
+		Password: string(hashed),
+		RoleId:   roleId,
+	}
+	user.SetCreatedAt(now)
+	user.SetUpatedAt(now)
+
+	if err := u.usersRepo.CreateUser(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (u usersUsecase) SignIn(ctx context.Context, req *models.UserSignIn) (*models.UserPassport, error) {
+	user, err := u.usersRepo.FetchUserByUsername(ctx, req.Username)
+	if err != nil {
+		if err.Error() == constants.ERROR_USER_NOT_FOUND {
+			return nil, errors.New(constants.ERROR_USER_CREDENTIAL_INVALID)
+		}
+		return nil, err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
+		return nil, errors.New(constants.ERROR_USER_CREDENTIAL_INVALID)
+	}
+
+	var now = helper.NewTimestampFromTime(time.Now())
+	var oauth = &models.Oauth{UserId: user.Id}
+	oauth.NewId()
+	oauth.SetCreatedAt(now)
+	oauth.SetUpatedAt(now)
+	if err := u.issueTokens(oauth, user); err != nil {
+		return nil, err
+	}
+
+	if err := u.usersRepo.CreateOauth(ctx, oauth); err != nil {
+		return nil, err
+	}
+
+	return &models.UserPassport{User: user, Token: oauth}, nil
+}
+
+/* RefreshToken ออก token คู่ใหม่แทนคู่เดิม (refresh token ใช้ได้ครั้งเดียว) */
+func (u usersUsecase) RefreshToken(ctx context.Context, refreshToken string) (*models.UserPassport, error) {
+	claims, err := auth.ParseToken(u.cfg, auth.Refresh, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	oauth, err := u.usersRepo.FetchOauthByRefreshToken(ctx, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	if oauth.UserId != claims.Claims.Id {
+		return nil, errors.New(constants.ERROR_TOKEN_INVALID)
+	}
+
+	user, err := u.usersRepo.FetchUserById(ctx, oauth.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	oauth.SetUpatedAt(helper.NewTimestampFromTime(time.Now()))
+	if err := u.issueTokens(oauth, user); err != nil {
+		return nil, err
+	}
+
+	if err := u.usersRepo.UpdateOauth(ctx, oauth); err != nil {
+		return nil, err
+	}
+
+	return &models.UserPassport{User: user, Token: oauth}, nil
+}
+
+func (u usersUsecase) SignOut(ctx context.Context, userId string, accessToken string) error {
+	return u.usersRepo.DeleteOauth(ctx, userId, accessToken)
+}
+
+/*
+Authenticate ตรวจ access token และ session ใน oauth ที่ยังไม่ถูก sign out
+คืน claims ตามข้อมูลผู้ใช้ปัจจุบัน การเปลี่ยน role จึงมีผลทันทีโดยไม่ต้องรอ token หมดอายุ
+*/
+func (u usersUsecase) Authenticate(ctx context.Context, accessToken string) (*models.UserClaims, error) {
+	claims, err := auth.ParseToken(u.cfg, auth.Access, accessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	oauth, err := u.usersRepo.FetchOauthByAccessToken(ctx, accessToken)
+	if err != nil {
+		if err.Error() == constants.ERROR_OAUTH_NOT_FOUND {
+			return nil, errors.New(constants.ERROR_TOKEN_REVOKED)
+		}
+		return nil, err
+	}
+	if oauth.UserId != claims.Claims.Id {
+		return nil, errors.New(constants.ERROR_TOKEN_INVALID)
+	}
+
+	user, err := u.usersRepo.FetchUserById(ctx, oauth.UserId)
+	if err != nil {
+		if err.Error() == constants.ERROR_USER_NOT_FOUND {
+			return nil, errors.New(constants.ERROR_TOKEN_REVOKED)
+		}
+		return nil, err
+	}
+
+	return user.Claims(), nil
+}
+
+func (u usersUsecase) issueTokens(oauth *models.Oauth, user *models.User) error {
+	accessToken, err := auth.NewAccessToken(u.cfg, user.Claims())
+	if err != nil {
+		return err
+	}
+	refreshToken, err := auth.NewRefreshToken(u.cfg, user.Claims())
+	if err != nil {
+		return err
+	}
+
+	oauth.AccessToken = accessToken
+	oauth.RefreshToken = refreshToken
+	return nil
+}
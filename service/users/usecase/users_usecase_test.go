@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"github/pheethy/todo/auth"
+	"github/pheethy/todo/constants"
+	"github/pheethy/todo/models"
+	"github/pheethy/todo/service/users/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type jwtConfig struct{}
+
+func (jwtConfig) AdminKey() []byte      { return []byte("76jfqJzzPJKhyKjk") }
+func (jwtConfig) SecretKey() []byte     { return []byte("6t7hkJmVr5U2L5WL") }
+func (jwtConfig) ApiKey() []byte        { return []byte("v2UeyF3xDtCMmNCG") }
+func (jwtConfig) AccessExpiresAt() int  { return 60 }
+func (jwtConfig) RefreshExpiresAt() int { return 120 }
+
+func TestSignIn(t *testing.T) {
+	hashed, _ := bcrypt.GenerateFromPassword([]byte("123456789"), bcrypt.MinCost)
+	user := &models.User{Id: "U000001", Username: "customer001", Password: string(hashed), RoleId: constants.ROLE_CUSTOMER}
+
+	t.Run("success", func(t *testing.T) {
+		repo := mocks.NewUsersRepository(t)
+		repo.On("FetchUserByUsername", mock.Anything, "customer001").Return(user, nil)
+		repo.On("CreateOauth", mock.Anything, mock.AnythingOfType("*models.Oauth")).Return(nil)
+
+		us := NewUsersUsecase(jwtConfig{}, repo)
+		passport, err := us.SignIn(context.Background(), &models.UserSignIn{Username: "customer001", Password: "123456789"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, user.Id, passport.Token.UserId)
+
+		claims, err := auth.ParseToken(jwtConfig{}, auth.Access, passport.Token.AccessToken)
+		assert.NoError(t, err)
+		assert.Equal(t, user.Id, claims.Claims.Id)
+	})
+
+	t.Run("wrong_password", func(t *testing.T) {
+		repo := mocks.NewUsersRepository(t)
+		repo.On("FetchUserByUsername", mock.Anything, "customer001").Return(user, nil)
+
+		us := NewUsersUsecase(jwtConfig{}, repo)
+		passport, err := us.SignIn(context.Background(), &models.UserSignIn{Username: "customer001", Password: "wrong-password"})
+
+		assert.Nil(t, passport)
+		assert.EqualError(t, err, constants.ERROR_USER_CREDENTIAL_INVALID)
+	})
+}
+
+func TestRefreshToken(t *testing.T) {
+	user := &models.User{Id: "U000001", Username: "customer001", RoleId: constants.ROLE_CUSTOMER}
+	refreshToken, _ := auth.NewRefreshToken(jwtConfig{}, user.Claims())
+	oauth := &models.Oauth{UserId: user.Id, RefreshToken: refreshToken}
+	oauth.NewId()
+
+	t.Run("success", func(t *testing.T) {
+		repo := mocks.NewUsersRepository(t)
+		repo.On("FetchOauthByRefreshToken", mock.Anything, refreshToken).Return(oauth, nil)
+		repo.On("FetchUserById", mock.Anything, user.Id).Return(user, nil)
+		repo.On("UpdateOauth", mock.Anything, oauth).Return(nil)
+
+		us := NewUsersUsecase(jwtConfig{}, repo)
+		passport, err := us.RefreshToken(context.Background(), refreshToken)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, passport.Token.AccessToken)
+	})
+
+	t.Run("access_token_rejected", func(t *testing.T) {
+		accessToken, _ := auth.NewAccessToken(jwtConfig{}, user.Claims())
+		repo := mocks.NewUsersRepository(t)
+
+		us := NewUsersUsecase(jwtConfig{}, repo)
+		passport, err := us.RefreshToken(context.Background(), accessToken)
+
+		assert.Nil(t, passport)
+		assert.EqualError(t, err, constants.ERROR_TOKEN_INVALID)
+	})
+}
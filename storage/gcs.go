@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	gcs "cloud.google.com/go/storage"
+)
+
+/* gcsStorage เก็บไฟล์ใน Google Cloud Storage ใช้ credential จาก GOOGLE_APPLICATION_CREDENTIALS */
+type gcsStorage struct {
+	client *gcs.Client
+	bucket string
+}
+
+func NewGCSStorage(ctx context.Context, bucket string) (Storage, error) {
+	client, err := gcs.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return gcsStorage{client: client, bucket: bucket}, nil
+}
+
+func (s gcsStorage) Upload(ctx context.Context, key string, r io.Reader, contentType string) (string, error) {
+	key = cleanKey(key)
+	writer := s.client.Bucket(s.bucket).Object(key).NewWriter(ctx)
+	writer.ContentType = contentType
+
+	if _, err := io.Copy(writer, r); err != nil {
+		writer.Close()
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("https://storage.googleapis.com/%s/%s", s.bucket, key), nil
+}
+
+func (s gcsStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	return s.client.Bucket(s.bucket).Object(cleanKey(key)).NewReader(ctx)
+}
+
+/* Delete object ที่ไม่มีอยู่แล้วไม่ถือเป็น error */
+func (s gcsStorage) Delete(ctx context.Context, key string) error {
+	err := s.client.Bucket(s.bucket).Object(cleanKey(key)).Delete(ctx)
+	if err != nil && !errors.Is(err, gcs.ErrObjectNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+const (
+	/* LocalDir โฟลเดอร์ที่ LocalStorage ใช้เก็บไฟล์ ถูก serve ที่ LocalUrlPrefix ใน main.go */
+	LocalDir       = "static"
+	LocalUrlPrefix = "/static"
+
+	/* AttachmentDir โฟลเดอร์เก็บไฟล์แนบของ task ห้าม serve เป็น static */
+	AttachmentDir = "uploads"
+)
+
+/* localStorage เก็บไฟล์ลง filesystem ใช้สำหรับ dev และ test */
+type localStorage struct {
+	dir       string
+	urlPrefix string
+}
+
+func NewLocalStorage(dir string, urlPrefix string) Storage {
+	return localStorage{dir: dir, urlPrefix: urlPrefix}
+}
+
+func (s localStorage) Upload(ctx context.Context, key string, r io.Reader, contentType string) (string, error) {
+	key = cleanKey(key)
+	dst := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return "", err
+	}
+
+	file, err := os.Create(dst)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(file, r); err != nil {
+		file.Close()
+		os.Remove(dst)
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+
+	return s.urlPrefix + "/" + key, nil
+}
+
+func (s localStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	return os.Open(filepath.Join(s.dir, filepath.FromSlash(cleanKey(key))))
+}
+
+/* Delete ไฟล์ที่ไม่มีอยู่แล้วไม่ถือเป็น error */
+func (s localStorage) Delete(ctx context.Context, key string) error {
+	err := os.Remove(filepath.Join(s.dir, filepath.FromSlash(cleanKey(key))))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
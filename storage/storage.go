@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"github/pheethy/todo/config"
+	"github/pheethy/todo/constants"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+)
+
+/* ImageContentTypes ชนิดไฟล์รูปที่อนุญาตให้อัปโหลด ตรวจจากเนื้อไฟล์ ไม่ใช่จากนามสกุลหรือ header ที่ client ส่งมา */
+var ImageContentTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+/* Storage ที่เก็บไฟล์ที่อัปโหลด key คือ path ของไฟล์ภายใน storage เช่น products/P000001/<uuid>.jpg */
+type Storage interface {
+	Upload(ctx context.Context, key string, r io.Reader, contentType string) (string, error)
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+/* NewStorage ใช้ GCS เมื่อกำหนด APP_GCP_BUCKET นอกนั้นเก็บไฟล์ใน LocalDir */
+func NewStorage(ctx context.Context, cfg config.IAppConfig) (Storage, error) {
+	if cfg.GCPBucket() != "" {
+		return NewGCSStorage(ctx, cfg.GCPBucket())
+	}
+	return NewLocalStorage(LocalDir, LocalUrlPrefix), nil
+}
+
+/*
+NewAttachmentStorage storage ของไฟล์แนบ task ใช้ GCS เฉพาะเมื่อกำหนด APP_GCP_ATTACHMENT_BUCKET ซึ่งต้องเป็น bucket แบบ private
+ไม่ใช้ APP_GCP_BUCKET เพราะ bucket นั้นเปิด public สำหรับรูปสินค้า นอกนั้นเก็บใน AttachmentDir ซึ่งไม่ถูก serve เป็น static
+ไฟล์แนบต้องดาวน์โหลดผ่าน API เท่านั้นเพื่อให้ตรวจสิทธิ์เจ้าของ task ได้
+*/
+func NewAttachmentStorage(ctx context.Context, cfg config.IAppConfig) (Storage, error) {
+	if cfg.GCPAttachmentBucket() != "" {
+		return NewGCSStorage(ctx, cfg.GCPAttachmentBucket())
+	}
+	return NewLocalStorage(AttachmentDir, ""), nil
+}
+
+/* SniffContentType อ่าน 512 byte แรกเพื่อหาชนิดไฟล์แล้ว seek กลับไปที่ต้นไฟล์ */
+func SniffContentType(r io.ReadSeeker) (string, error) {
+	buf := make([]byte, 512)
+	n, err := r.Read(buf)
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	return http.DetectContentType(buf[:n]), nil
+}
+
+/* ValidateImage ตรวจขนาดไฟล์ตาม APP_FILE_LIMIT และชนิดไฟล์ คืนนามสกุลที่ใช้ตั้งชื่อไฟล์ */
+func ValidateImage(r io.ReadSeeker, size int64, limit int) (string, string, error) {
+	if limit > 0 && size > int64(limit) {
+		return "", "", errors.New(constants.ERROR_FILE_TOO_LARGE)
+	}
+	contentType, err := SniffContentType(r)
+	if err != nil {
+		return "", "", err
+	}
+	ext, ok := ImageContentTypes[contentType]
+	if !ok {
+		return "", "", errors.New(constants.ERROR_FILE_TYPE_INVALID)
+	}
+	return contentType, ext, nil
+}
+
+/* cleanKey กัน key ที่พยายามออกนอก root ของ storage เช่น ../../etc/passwd */
+func cleanKey(key string) string {
+	return strings.TrimPrefix(path.Clean("/"+key), "/")
+}
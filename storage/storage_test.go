@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"github/pheethy/todo/config"
+	"github/pheethy/todo/constants"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/* png ขนาด 1x1 pixel */
+var pngImage = []byte{
+	0x89, 0x50, 0x4e, 0x47, 0x0d, 0x0a, 0x1a, 0x0a, 0x00, 0x00, 0x00, 0x0d, 0x49, 0x48, 0x44, 0x52,
+	0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 0x08, 0x06, 0x00, 0x00, 0x00, 0x1f, 0x15, 0xc4,
+	0x89, 0x00, 0x00, 0x00, 0x0d, 0x49, 0x44, 0x41, 0x54, 0x78, 0x9c, 0x63, 0x00, 0x01, 0x00, 0x00,
+	0x05, 0x00, 0x01, 0x0d, 0x0a, 0x2d, 0xb4, 0x00, 0x00, 0x00, 0x00, 0x49, 0x45, 0x4e, 0x44, 0xae,
+	0x42, 0x60, 0x82,
+}
+
+func TestValidateImage(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		contentType, ext, err := ValidateImage(bytes.NewReader(pngImage), int64(len(pngImage)), 1024)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "image/png", contentType)
+		assert.Equal(t, ".png", ext)
+	})
+
+	t.Run("too_large", func(t *testing.T) {
+		_, _, err := ValidateImage(bytes.NewReader(pngImage), int64(len(pngImage)), 10)
+
+		assert.EqualError(t, err, constants.ERROR_FILE_TOO_LARGE)
+	})
+
+	t.Run("not_an_image", func(t *testing.T) {
+		/* นามสกุลหรือ Content-Type ที่ client ส่งมาไม่มีผล ดูจากเนื้อไฟล์เท่านั้น */
+		body := []byte("<html><script>alert(1)</script></html>")
+		_, _, err := ValidateImage(bytes.NewReader(body), int64(len(body)), 1024)
+
+		assert.EqualError(t, err, constants.ERROR_FILE_TYPE_INVALID)
+	})
+}
+
+func TestLocalStorage(t *testing.T) {
+	var ctx = context.Background()
+	dir := t.TempDir()
+	store := NewLocalStorage(dir, LocalUrlPrefix)
+
+	url, err := store.Upload(ctx, "products/P000001/image.png", bytes.NewReader(pngImage), "image/png")
+	assert.NoError(t, err)
+	assert.Equal(t, "/static/products/P000001/image.png", url)
+
+	saved, err := os.ReadFile(filepath.Join(dir, "products", "P000001", "image.png"))
+	assert.NoError(t, err)
+	assert.Equal(t, pngImage, saved)
+
+	assert.NoError(t, store.Delete(ctx, "products/P000001/image.png"))
+	assert.NoError(t, store.Delete(ctx, "products/P000001/image.png"))
+	_, err = os.Stat(filepath.Join(dir, "products", "P000001", "image.png"))
+	assert.True(t, os.IsNotExist(err))
+
+	t.Run("key_can_not_escape_dir", func(t *testing.T) {
+		url, err := store.Upload(ctx, "../../escape.png", bytes.NewReader(pngImage), "image/png")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "/static/escape.png", url)
+		assert.FileExists(t, filepath.Join(dir, "escape.png"))
+	})
+}
+
+func TestNewAttachmentStorage(t *testing.T) {
+	/* APP_GCP_BUCKET เป็น bucket public ของรูปสินค้า ไฟล์แนบต้องไม่ถูกเก็บที่นั่น */
+	cfg, err := config.FromEnv(map[string]string{
+		"APP_PORT": "8080", "APP_READ_TIMEOUT": "60", "APP_WRTIE_TIMEOUT": "60",
+		"APP_BODY_LIMIT": "10490000", "APP_FILE_LIMIT": "2097000", "APP_GCP_BUCKET": "public-images",
+		"JWT_ADMIN_KEY": "76jfqJzzPJKhyKjk", "JWT_SECRET_KEY": "6t7hkJmVr5U2L5WL", "JWT_API_KEY": "v2UeyF3xDtCMmNCG",
+		"JWT_ACCESS_EXPIRES": "86400", "JWT_REFRESH_EXPIRES": "604800",
+		"DB_HOST": "127.0.0.1", "DB_PORT": "5432", "DB_USERNAME": "postgres", "DB_DATABASE": "pheety_db_test", "DB_MAX_CONNECTIONS": "25",
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	s, err := NewAttachmentStorage(context.Background(), cfg.App())
+	assert.NoError(t, err)
+	assert.Equal(t, NewLocalStorage(AttachmentDir, ""), s)
+}